@@ -13,6 +13,7 @@ import (
 	"github.com/keptn-contrib/dynatrace-service/internal/event_handler"
 	"github.com/keptn-contrib/dynatrace-service/internal/health"
 	"github.com/keptn-contrib/dynatrace-service/internal/onboard"
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic"
 
 	log "github.com/sirupsen/logrus"
 
@@ -70,6 +71,8 @@ func _main(envCfg envConfig) int {
 		}()
 	}
 
+	resumeSyntheticBatches(workCtx, replyCtx, workerWaitGroup)
+
 	log.WithFields(log.Fields{"port": envCfg.Port, "path": envCfg.Path}).Debug("Initializing cloudevents client")
 	c, err := cloudevents.NewClientHTTP(cloudevents.WithPath(envCfg.Path), cloudevents.WithPort(envCfg.Port), cloudevents.WithGetHandlerFunc(health.HTTPGetHandler))
 	if err != nil {
@@ -106,6 +109,37 @@ func _main(envCfg envConfig) int {
 	return 0
 }
 
+// resumeSyntheticBatches resumes waiting for the batches of all synthetic tests which were not finished before the last shutdown.
+// As all batches of the store are resumed, it must not be shared by several running instances, which is why the chart deploys a single replica using the Recreate strategy.
+func resumeSyntheticBatches(workCtx context.Context, replyCtx context.Context, workerWaitGroup *sync.WaitGroup) {
+	batchStates, err := synthetic.NewDefaultBatchStateStore()
+	if err != nil {
+		log.WithError(err).Error("Could not create synthetic batch state store")
+		return
+	}
+
+	if batchStates == nil {
+		return
+	}
+
+	states, err := batchStates.List(workCtx)
+	if err != nil {
+		log.WithError(err).Error("Could not list synthetic batches to resume")
+		return
+	}
+
+	for _, state := range states {
+		workerWaitGroup.Add(1)
+		go func(state synthetic.BatchState) {
+			defer workerWaitGroup.Done()
+			err := event_handler.ResumeSyntheticBatch(workCtx, replyCtx, batchStates, state)
+			if err != nil {
+				log.WithError(err).Error("ResumeSyntheticBatch() returned an error")
+			}
+		}(state)
+	}
+}
+
 func gotEvent(workCtx context.Context, replyCtx context.Context, event cloudevents.Event) {
 	err := event_handler.NewEventHandler(workCtx, event).HandleEvent(workCtx, replyCtx)
 	if err != nil {
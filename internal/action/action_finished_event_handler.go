@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
@@ -52,7 +53,7 @@ func (eh *ActionFinishedEventHandler) HandleEvent(workCtx context.Context, reply
 		configurationEvent := dynatrace.ConfigurationEvent{
 			EventType:        dynatrace.ConfigurationEventType,
 			Description:      "Keptn Remediation Action Finished",
-			Source:           eventSource,
+			Source:           common.EventSource,
 			Configuration:    "successful",
 			CustomProperties: customProperties,
 			AttachRules:      *eh.attachRules,
@@ -62,7 +63,7 @@ func (eh *ActionFinishedEventHandler) HandleEvent(workCtx context.Context, reply
 	} else {
 		infoEvent := dynatrace.InfoEvent{
 			EventType:        dynatrace.InfoEventType,
-			Source:           eventSource,
+			Source:           common.EventSource,
 			Title:            "Keptn Remediation Action Finished",
 			Description:      "error during execution",
 			CustomProperties: customProperties,
@@ -3,6 +3,7 @@ package action
 import (
 	"context"
 
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
 )
@@ -31,7 +32,7 @@ func (eh *DeploymentFinishedEventHandler) HandleEvent(workCtx context.Context, r
 
 	deploymentEvent := dynatrace.DeploymentEvent{
 		EventType:         dynatrace.DeploymentEventType,
-		Source:            eventSource,
+		Source:            common.EventSource,
 		DeploymentName:    getValueFromLabels(eh.event, "deploymentName", "Deploy "+eh.event.GetService()+" "+imageAndTag.Tag()+" with strategy "+eh.event.GetDeploymentStrategy()),
 		DeploymentProject: getValueFromLabels(eh.event, "deploymentProject", eh.event.GetProject()),
 		DeploymentVersion: getValueFromLabels(eh.event, "deploymentVersion", imageAndTag.Tag()),
@@ -56,6 +56,11 @@ func (a CloudEventAdapter) GetType() string {
 	return a.ce.Type()
 }
 
+// GetCloudEvent returns the adapted cloud event, e.g. to persist it.
+func (a CloudEventAdapter) GetCloudEvent() cloudevents.Event {
+	return a.ce
+}
+
 // PayloadAs attempts to populate the provided content object with the event payload. Will return an error otherwise.
 // content should be a pointer type.
 func (a CloudEventAdapter) PayloadAs(content interface{}) error {
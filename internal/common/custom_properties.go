@@ -0,0 +1,38 @@
+package common
+
+import (
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+)
+
+// EventSource is the source of the events sent to Dynatrace.
+const EventSource = "Keptn dynatrace-service"
+
+// BridgeURLKey is the custom property of events sent to Dynatrace which links to the Keptn's Bridge.
+const BridgeURLKey = "Keptns Bridge"
+
+// CreateCustomProperties creates the custom properties of an event sent to Dynatrace for the Keptn event, adding the specified properties.
+// The labels of the Keptn event are added as custom properties as well and take precedence. The bridge URL is only added if it is not empty.
+func CreateCustomProperties(a adapter.EventContentAdapter, properties map[string]string, bridgeURL string) map[string]string {
+	customProperties := map[string]string{
+		"Project":       a.GetProject(),
+		"Stage":         a.GetStage(),
+		"Service":       a.GetService(),
+		"KeptnContext":  a.GetShKeptnContext(),
+		"Keptn Service": a.GetSource(),
+	}
+
+	for key, value := range properties {
+		customProperties[key] = value
+	}
+
+	// now add the rest of the labels into custom properties (changed with #115_116)
+	for key, value := range a.GetLabels() {
+		customProperties[key] = value
+	}
+
+	if bridgeURL != "" {
+		customProperties[BridgeURLKey] = bridgeURL
+	}
+
+	return customProperties
+}
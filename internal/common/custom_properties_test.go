@@ -0,0 +1,36 @@
+package common
+
+import (
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCreateCustomProperties(t *testing.T) {
+	event := &test.EventData{
+		Context: "context-1",
+		Source:  "shipyard-controller",
+		Project: "easytravel",
+		Stage:   "staging",
+		Service: "frontend",
+		Labels: map[string]string{
+			"owner": "team-a",
+			"Image": "labeled-image",
+		},
+	}
+
+	assert.Equal(t, map[string]string{
+		"Project":       "easytravel",
+		"Stage":         "staging",
+		"Service":       "frontend",
+		"KeptnContext":  "context-1",
+		"Keptn Service": "shipyard-controller",
+		"Image":         "labeled-image",
+		"Tag":           "1.0.0",
+		"owner":         "team-a",
+		BridgeURLKey:    "https://bridge/trace/context-1",
+	}, CreateCustomProperties(event, map[string]string{"Image": "frontend", "Tag": "1.0.0"}, "https://bridge/trace/context-1"))
+
+	assert.NotContains(t, CreateCustomProperties(event, nil, ""), BridgeURLKey)
+}
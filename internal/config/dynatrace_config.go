@@ -8,6 +8,78 @@ type DynatraceConfig struct {
 	DtCreds     string                 `json:"dtCreds,omitempty" yaml:"dtCreds,omitempty"`
 	Dashboard   string                 `json:"dashboard,omitempty" yaml:"dashboard,omitempty"`
 	AttachRules *dynatrace.AttachRules `json:"attachRules,omitempty" yaml:"attachRules,omitempty"`
+	Synthetic   *SyntheticConfig       `json:"synthetic,omitempty" yaml:"synthetic,omitempty"`
+}
+
+// SyntheticConfig defines the configuration used when triggering synthetic tests
+type SyntheticConfig struct {
+	SyntheticWaitConfig       `yaml:",inline"`
+	SyntheticExecutionOptions `yaml:",inline"`
+	Locations                 []string             `json:"locations,omitempty" yaml:"locations,omitempty"`
+	Thresholds                *SyntheticThresholds `json:"thresholds,omitempty" yaml:"thresholds,omitempty"`
+	Comparison                *SyntheticComparison `json:"comparison,omitempty" yaml:"comparison,omitempty"`
+	Quarantine                *SyntheticQuarantine `json:"quarantine,omitempty" yaml:"quarantine,omitempty"`
+	Retries                   *int                 `json:"retries,omitempty" yaml:"retries,omitempty"`
+	EnableDisabledMonitors    *bool                `json:"enableDisabledMonitors,omitempty" yaml:"enableDisabledMonitors,omitempty"`
+}
+
+// SyntheticThresholds defines the thresholds the results of a synthetic batch have to satisfy to pass or to result in a warning.
+// If no pass threshold is defined, the batch always passes.
+type SyntheticThresholds struct {
+	Pass    *SyntheticThreshold `json:"pass,omitempty" yaml:"pass,omitempty"`
+	Warning *SyntheticThreshold `json:"warning,omitempty" yaml:"warning,omitempty"`
+}
+
+// SyntheticComparison enables comparing the results of a synthetic test with the previous test of the service in the same stage.
+// A test regressed if monitors fail which succeeded in the previous test or if response times increased by more than MaxResponseTimeIncrease.
+type SyntheticComparison struct {
+	// MaxResponseTimeIncrease is the percentage the response time of a monitor at a location may increase, response times are not compared if it is not set
+	MaxResponseTimeIncrease *float64 `json:"maxResponseTimeIncrease,omitempty" yaml:"maxResponseTimeIncrease,omitempty"`
+}
+
+// SyntheticQuarantine defines the monitors which are still triggered and reported, but excluded from the result of a synthetic test.
+type SyntheticQuarantine struct {
+	MonitorIds []string `json:"monitorIds,omitempty" yaml:"monitorIds,omitempty"`
+	// MinFlakinessScore additionally quarantines monitors whose flakiness score at any location reaches it, monitors are not quarantined based on their flakiness if it is not set
+	MinFlakinessScore *float64 `json:"minFlakinessScore,omitempty" yaml:"minFlakinessScore,omitempty"`
+}
+
+// SyntheticThreshold defines limits for the results of a synthetic batch. Limits which are not set are not checked.
+type SyntheticThreshold struct {
+	MinSuccessRate      *float64 `json:"minSuccessRate,omitempty" yaml:"minSuccessRate,omitempty"`
+	MaxFailedExecutions *int     `json:"maxFailedExecutions,omitempty" yaml:"maxFailedExecutions,omitempty"`
+	MaxFailedTriggers   *int     `json:"maxFailedTriggers,omitempty" yaml:"maxFailedTriggers,omitempty"`
+}
+
+// SyntheticExecutionOptions defines options applied to the executions of a synthetic batch. Options which are not set use the defaults of Dynatrace.
+type SyntheticExecutionOptions struct {
+	ProcessingMode           string                     `json:"processingMode,omitempty" yaml:"processingMode,omitempty"`
+	FailOnPerformanceIssue   *bool                      `json:"failOnPerformanceIssue,omitempty" yaml:"failOnPerformanceIssue,omitempty"`
+	FailOnSslWarning         *bool                      `json:"failOnSslWarning,omitempty" yaml:"failOnSslWarning,omitempty"`
+	StopOnProblem            *bool                      `json:"stopOnProblem,omitempty" yaml:"stopOnProblem,omitempty"`
+	TakeScreenshotsOnSuccess *bool                      `json:"takeScreenshotsOnSuccess,omitempty" yaml:"takeScreenshotsOnSuccess,omitempty"`
+	CustomizedScript         *SyntheticCustomizedScript `json:"customizedScript,omitempty" yaml:"customizedScript,omitempty"`
+	BaseUrl                  string                     `json:"baseUrl,omitempty" yaml:"baseUrl,omitempty"`
+}
+
+// SyntheticCustomizedScript customizes the scripts of all triggered monitors for a single batch
+type SyntheticCustomizedScript struct {
+	RequestHeaders []SyntheticRequestHeader `json:"requestHeaders,omitempty" yaml:"requestHeaders,omitempty"`
+}
+
+// SyntheticRequestHeader defines an HTTP header added to all requests of the triggered monitors
+type SyntheticRequestHeader struct {
+	Name  string `json:"name" yaml:"name"`
+	Value string `json:"value" yaml:"value"`
+}
+
+// SyntheticWaitConfig defines how long and how often the service polls for synthetic results.
+// Durations are specified as Go duration strings, e.g. "10m" or "30s".
+type SyntheticWaitConfig struct {
+	WaitTimeout       string  `json:"waitTimeout,omitempty" yaml:"waitTimeout,omitempty"`
+	WaitInterval      string  `json:"waitInterval,omitempty" yaml:"waitInterval,omitempty"`
+	WaitBackoffFactor float64 `json:"waitBackoffFactor,omitempty" yaml:"waitBackoffFactor,omitempty"`
+	WaitMaxInterval   string  `json:"waitMaxInterval,omitempty" yaml:"waitMaxInterval,omitempty"`
 }
 
 // NewDynatraceConfigWithDefaults returns a new DynatraceConfig with values set to defaults
@@ -17,5 +89,6 @@ func NewDynatraceConfigWithDefaults() *DynatraceConfig {
 		DtCreds:     "dynatrace",
 		Dashboard:   "",
 		AttachRules: nil,
+		Synthetic:   nil,
 	}
 }
@@ -54,6 +54,43 @@ func replacePlaceholdersInDynatraceConfig(dynatraceConfig *DynatraceConfig, even
 		DtCreds:     common.ReplaceKeptnPlaceholders(dynatraceConfig.DtCreds, event),
 		Dashboard:   common.ReplaceKeptnPlaceholders(dynatraceConfig.Dashboard, event),
 		AttachRules: replacePlaceholdersInAttachRules(dynatraceConfig.AttachRules, event),
+		Synthetic:   replacePlaceholdersInSyntheticConfig(dynatraceConfig.Synthetic, event),
+	}
+}
+
+func replacePlaceholdersInSyntheticConfig(syntheticConfig *SyntheticConfig, event adapter.EventContentAdapter) *SyntheticConfig {
+	if syntheticConfig == nil {
+		return nil
+	}
+
+	replaced := *syntheticConfig
+
+	replaced.Locations = nil
+	for _, location := range syntheticConfig.Locations {
+		replaced.Locations = append(replaced.Locations, common.ReplaceKeptnPlaceholders(location, event))
+	}
+
+	replaced.CustomizedScript = replacePlaceholdersInCustomizedScript(syntheticConfig.CustomizedScript, event)
+	replaced.BaseUrl = common.ReplaceKeptnPlaceholders(syntheticConfig.BaseUrl, event)
+
+	return &replaced
+}
+
+func replacePlaceholdersInCustomizedScript(customizedScript *SyntheticCustomizedScript, event adapter.EventContentAdapter) *SyntheticCustomizedScript {
+	if customizedScript == nil {
+		return nil
+	}
+
+	requestHeadersWithReplacedPlaceholders := make([]SyntheticRequestHeader, 0, len(customizedScript.RequestHeaders))
+	for _, requestHeader := range customizedScript.RequestHeaders {
+		requestHeadersWithReplacedPlaceholders = append(requestHeadersWithReplacedPlaceholders, SyntheticRequestHeader{
+			Name:  requestHeader.Name,
+			Value: common.ReplaceKeptnPlaceholders(requestHeader.Value, event),
+		})
+	}
+
+	return &SyntheticCustomizedScript{
+		RequestHeaders: requestHeadersWithReplacedPlaceholders,
 	}
 }
 
@@ -9,6 +9,9 @@ import (
 )
 
 func Test_parseDynatraceConfigYAML(t *testing.T) {
+	retries := 2
+	enableDisabledMonitors := true
+
 	tests := []struct {
 		name       string
 		yamlString string
@@ -45,6 +48,34 @@ dashboard: dash`,
 			},
 			wantErr: false,
 		},
+		{
+			name: "valid yaml with synthetic configuration",
+			yamlString: `
+spec_version: '0.1.0'
+dtCreds: dyna
+synthetic:
+  waitTimeout: 10m
+  waitInterval: 15s
+  waitBackoffFactor: 1.5
+  waitMaxInterval: 1m
+  retries: 2
+  enableDisabledMonitors: true`,
+			want: &DynatraceConfig{
+				SpecVersion: "0.1.0",
+				DtCreds:     "dyna",
+				Synthetic: &SyntheticConfig{
+					SyntheticWaitConfig: SyntheticWaitConfig{
+						WaitTimeout:       "10m",
+						WaitInterval:      "15s",
+						WaitBackoffFactor: 1.5,
+						WaitMaxInterval:   "1m",
+					},
+					Retries:                &retries,
+					EnableDisabledMonitors: &enableDisabledMonitors,
+				},
+			},
+			wantErr: false,
+		},
 		{
 			name: "invalid yaml",
 			yamlString: `
@@ -85,6 +116,10 @@ dashboard: '****'`,
 
 // TestDynatraceConfigGetter_GetDynatraceConfig tests that placeholders are replaced correctly using data from an event.
 func TestDynatraceConfigGetter_GetDynatraceConfig(t *testing.T) {
+	takeScreenshotsOnSuccess := true
+	maxResponseTimeIncrease := 20.0
+	minFlakinessScore := 0.5
+
 	mockEvent := test.EventData{
 		Context:            "01234567-0123-0123-0123-012345678901",
 		Event:              "sh.keptn.event.get-sli.triggered",
@@ -232,6 +267,90 @@ dashboard: $LABEL.dashboard`,
 				AttachRules: &expectedDefaultAttachRules,
 			},
 		},
+		{
+			name: "Test with synthetic locations",
+			configString: `spec_version: '0.1.0'
+dtCreds: dynatrace-$PROJECT
+dashboard: $LABEL.dashboard
+synthetic:
+  locations:
+  - GEOLOCATION-1
+  - $STAGE ActiveGate`,
+			wantConfig: DynatraceConfig{
+				SpecVersion: "0.1.0",
+				DtCreds:     "dynatrace-myproject",
+				Dashboard:   "12345678-1111-4444-8888-123456789012",
+				AttachRules: &expectedDefaultAttachRules,
+				Synthetic: &SyntheticConfig{
+					Locations: []string{"GEOLOCATION-1", "mystage ActiveGate"},
+				},
+			},
+		},
+		{
+			name: "Test with synthetic execution options",
+			configString: `spec_version: '0.1.0'
+dtCreds: dynatrace-$PROJECT
+synthetic:
+  processingMode: DISABLE_PROBLEM_DETECTION
+  takeScreenshotsOnSuccess: true
+  baseUrl: https://$DEPLOYMENT.$SERVICE.example.com
+  customizedScript:
+    requestHeaders:
+    - name: X-Keptn-Service
+      value: $SERVICE`,
+			wantConfig: DynatraceConfig{
+				SpecVersion: "0.1.0",
+				DtCreds:     "dynatrace-myproject",
+				AttachRules: &expectedDefaultAttachRules,
+				Synthetic: &SyntheticConfig{
+					SyntheticExecutionOptions: SyntheticExecutionOptions{
+						ProcessingMode:           "DISABLE_PROBLEM_DETECTION",
+						TakeScreenshotsOnSuccess: &takeScreenshotsOnSuccess,
+						CustomizedScript: &SyntheticCustomizedScript{
+							RequestHeaders: []SyntheticRequestHeader{{Name: "X-Keptn-Service", Value: "myservice"}},
+						},
+						BaseUrl: "https://mydeployment.myservice.example.com",
+					},
+				},
+			},
+		},
+		{
+			name: "Test with synthetic comparison",
+			configString: `spec_version: '0.1.0'
+dtCreds: dynatrace-$PROJECT
+synthetic:
+  comparison:
+    maxResponseTimeIncrease: 20`,
+			wantConfig: DynatraceConfig{
+				SpecVersion: "0.1.0",
+				DtCreds:     "dynatrace-myproject",
+				AttachRules: &expectedDefaultAttachRules,
+				Synthetic: &SyntheticConfig{
+					Comparison: &SyntheticComparison{MaxResponseTimeIncrease: &maxResponseTimeIncrease},
+				},
+			},
+		},
+		{
+			name: "Test with synthetic quarantine",
+			configString: `spec_version: '0.1.0'
+dtCreds: dynatrace-$PROJECT
+synthetic:
+  quarantine:
+    monitorIds:
+    - HTTP_CHECK-1
+    minFlakinessScore: 0.5`,
+			wantConfig: DynatraceConfig{
+				SpecVersion: "0.1.0",
+				DtCreds:     "dynatrace-myproject",
+				AttachRules: &expectedDefaultAttachRules,
+				Synthetic: &SyntheticConfig{
+					Quarantine: &SyntheticQuarantine{
+						MonitorIds:        []string{"HTTP_CHECK-1"},
+						MinFlakinessScore: &minFlakinessScore,
+					},
+				},
+			},
+		},
 		{
 			name: "Test with label that does not exist",
 			configString: `spec_version: '0.1.0'
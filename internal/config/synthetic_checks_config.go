@@ -0,0 +1,118 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// SyntheticChecksConfig defines the structure of the synthetic-checks.yaml declaring the HTTP checks executed by the service itself
+type SyntheticChecksConfig struct {
+	SpecVersion string `json:"spec_version" yaml:"spec_version"`
+
+	// Location is the name of the location the checks are reported for. Defaults to Keptn.
+	Location string                      `json:"location,omitempty" yaml:"location,omitempty"`
+	Checks   []SyntheticCheckDeclaration `json:"checks,omitempty" yaml:"checks,omitempty"`
+}
+
+// SyntheticCheckDeclaration declares an HTTP check executed by the service itself. Checks are identified by their name.
+type SyntheticCheckDeclaration struct {
+	Name    string                   `json:"name" yaml:"name"`
+	Method  string                   `json:"method,omitempty" yaml:"method,omitempty"`
+	Url     string                   `json:"url" yaml:"url"`
+	Headers []SyntheticRequestHeader `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Body    string                   `json:"body,omitempty" yaml:"body,omitempty"`
+
+	// ExpectedStatus is the expected HTTP status code. By default, any status code below 400 passes.
+	ExpectedStatus int `json:"expectedStatus,omitempty" yaml:"expectedStatus,omitempty"`
+
+	// BodyRegex is a regular expression the response body has to match
+	BodyRegex string `json:"bodyRegex,omitempty" yaml:"bodyRegex,omitempty"`
+
+	// MaxLatency is the maximum time until the response body is received, e.g. 500ms
+	MaxLatency string `json:"maxLatency,omitempty" yaml:"maxLatency,omitempty"`
+
+	// Retries is how often a failed check is repeated. Defaults to 0.
+	Retries int `json:"retries,omitempty" yaml:"retries,omitempty"`
+}
+
+// GetMethod returns the HTTP method of the check, GET if none is defined.
+func (d SyntheticCheckDeclaration) GetMethod() string {
+	if d.Method == "" {
+		return http.MethodGet
+	}
+
+	return strings.ToUpper(d.Method)
+}
+
+// GetMaxLatency returns the maximum latency of the check or 0 if none is defined.
+func (d SyntheticCheckDeclaration) GetMaxLatency() (time.Duration, error) {
+	if d.MaxLatency == "" {
+		return 0, nil
+	}
+
+	maxLatency, err := time.ParseDuration(d.MaxLatency)
+	if err != nil {
+		return 0, err
+	}
+
+	if maxLatency <= 0 {
+		return 0, errors.New("must be positive")
+	}
+
+	return maxLatency, nil
+}
+
+// Validate checks that the check declaration is complete and uses supported values.
+func (d SyntheticCheckDeclaration) Validate() error {
+	if d.Name == "" {
+		return errors.New("check has no name")
+	}
+
+	if d.Url == "" {
+		return fmt.Errorf("check '%s' has no url", d.Name)
+	}
+
+	if d.ExpectedStatus != 0 && (d.ExpectedStatus < 100 || d.ExpectedStatus > 599) {
+		return fmt.Errorf("check '%s' has an invalid expected status %d", d.Name, d.ExpectedStatus)
+	}
+
+	if _, err := regexp.Compile(d.BodyRegex); err != nil {
+		return fmt.Errorf("check '%s' has an invalid body regex: %v", d.Name, err)
+	}
+
+	if _, err := d.GetMaxLatency(); err != nil {
+		return fmt.Errorf("check '%s' has an invalid max latency '%s': %v", d.Name, d.MaxLatency, err)
+	}
+
+	if d.Retries < 0 {
+		return fmt.Errorf("check '%s' has a negative number of retries", d.Name)
+	}
+
+	return nil
+}
+
+// Validate checks that there is at least one check, that all checks are valid and that their names are unique.
+func (c SyntheticChecksConfig) Validate() error {
+	if len(c.Checks) == 0 {
+		return errors.New("no checks are declared")
+	}
+
+	names := make(map[string]bool, len(c.Checks))
+	for _, check := range c.Checks {
+		err := check.Validate()
+		if err != nil {
+			return err
+		}
+
+		if names[check.Name] {
+			return fmt.Errorf("check '%s' is declared more than once", check.Name)
+		}
+		names[check.Name] = true
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+
+	"gopkg.in/yaml.v2"
+)
+
+type SyntheticChecksConfigProvider interface {
+	GetSyntheticChecksConfig(event adapter.EventContentAdapter) (*SyntheticChecksConfig, error)
+}
+
+type SyntheticChecksConfigGetter struct {
+	resourceClient keptn.SyntheticChecksReaderInterface
+}
+
+func NewSyntheticChecksConfigGetter(client keptn.SyntheticChecksReaderInterface) *SyntheticChecksConfigGetter {
+	return &SyntheticChecksConfigGetter{
+		resourceClient: client,
+	}
+}
+
+// GetSyntheticChecksConfig loads the synthetic-checks.yaml from the GIT repo
+func (g *SyntheticChecksConfigGetter) GetSyntheticChecksConfig(event adapter.EventContentAdapter) (*SyntheticChecksConfig, error) {
+	fileContent, err := g.resourceClient.GetSyntheticChecks(event.GetProject(), event.GetStage(), event.GetService())
+	if err != nil {
+		return nil, err
+	}
+
+	checksConfig, err := parseSyntheticChecksYAML(fileContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse synthetic checks file found for service %s in stage %s in project %s: %s", event.GetService(), event.GetStage(), event.GetProject(), err.Error())
+	}
+
+	checksConfig = replacePlaceholdersInSyntheticChecksConfig(checksConfig, event)
+
+	err = checksConfig.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid synthetic checks file found for service %s in stage %s in project %s: %s", event.GetService(), event.GetStage(), event.GetProject(), err.Error())
+	}
+
+	return checksConfig, nil
+}
+
+func replacePlaceholdersInSyntheticChecksConfig(checksConfig *SyntheticChecksConfig, event adapter.EventContentAdapter) *SyntheticChecksConfig {
+	checksWithReplacedPlaceholders := make([]SyntheticCheckDeclaration, 0, len(checksConfig.Checks))
+	for _, check := range checksConfig.Checks {
+		var headersWithReplacedPlaceholders []SyntheticRequestHeader
+		for _, header := range check.Headers {
+			headersWithReplacedPlaceholders = append(headersWithReplacedPlaceholders, SyntheticRequestHeader{
+				Name:  header.Name,
+				Value: common.ReplaceKeptnPlaceholders(header.Value, event),
+			})
+		}
+
+		checksWithReplacedPlaceholders = append(checksWithReplacedPlaceholders, SyntheticCheckDeclaration{
+			Name:           common.ReplaceKeptnPlaceholders(check.Name, event),
+			Method:         check.Method,
+			Url:            common.ReplaceKeptnPlaceholders(check.Url, event),
+			Headers:        headersWithReplacedPlaceholders,
+			Body:           common.ReplaceKeptnPlaceholders(check.Body, event),
+			ExpectedStatus: check.ExpectedStatus,
+			BodyRegex:      check.BodyRegex,
+			MaxLatency:     check.MaxLatency,
+			Retries:        check.Retries,
+		})
+	}
+
+	return &SyntheticChecksConfig{
+		SpecVersion: checksConfig.SpecVersion,
+		Location:    common.ReplaceKeptnPlaceholders(checksConfig.Location, event),
+		Checks:      checksWithReplacedPlaceholders,
+	}
+}
+
+func parseSyntheticChecksYAML(input string) (*SyntheticChecksConfig, error) {
+	checksConfig := &SyntheticChecksConfig{}
+	err := yaml.Unmarshal([]byte(input), checksConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return checksConfig, nil
+}
@@ -0,0 +1,141 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSyntheticChecksConfigGetter_GetSyntheticChecksConfig tests that the synthetic-checks.yaml is parsed and validated and that placeholders are replaced.
+func TestSyntheticChecksConfigGetter_GetSyntheticChecksConfig(t *testing.T) {
+	mockEvent := test.EventData{
+		Context: "01234567-0123-0123-0123-012345678901",
+		Event:   "sh.keptn.event.test.triggered",
+		Project: "myproject",
+		Stage:   "mystage",
+		Service: "myservice",
+		Labels: map[string]string{
+			"token": "secret",
+		},
+	}
+
+	tests := []struct {
+		name         string
+		configString string
+		wantConfig   *SyntheticChecksConfig
+		wantErr      string
+	}{
+		{
+			name: "checks with placeholders",
+			configString: `---
+spec_version: '0.1.0'
+location: $STAGE cluster
+checks:
+  - name: $SERVICE health
+    url: http://$SERVICE.$PROJECT-$STAGE/health
+    headers:
+      - name: Authorization
+        value: Bearer $LABEL.token
+    expectedStatus: 200
+    bodyRegex: '"status":\s*"UP"'
+    maxLatency: 500ms
+    retries: 2
+  - name: $SERVICE login
+    method: post
+    url: http://$SERVICE.$PROJECT-$STAGE/login
+    body: '{"user":"$SERVICE"}'`,
+			wantConfig: &SyntheticChecksConfig{
+				SpecVersion: "0.1.0",
+				Location:    "mystage cluster",
+				Checks: []SyntheticCheckDeclaration{
+					{
+						Name:           "myservice health",
+						Url:            "http://myservice.myproject-mystage/health",
+						Headers:        []SyntheticRequestHeader{{Name: "Authorization", Value: "Bearer secret"}},
+						ExpectedStatus: 200,
+						BodyRegex:      `"status":\s*"UP"`,
+						MaxLatency:     "500ms",
+						Retries:        2,
+					},
+					{
+						Name:   "myservice login",
+						Method: "post",
+						Url:    "http://myservice.myproject-mystage/login",
+						Body:   `{"user":"myservice"}`,
+					},
+				},
+			},
+		},
+		{
+			name:         "no checks",
+			configString: `spec_version: '0.1.0'`,
+			wantErr:      "no checks are declared",
+		},
+		{
+			name: "missing url",
+			configString: `checks:
+  - name: health`,
+			wantErr: "check 'health' has no url",
+		},
+		{
+			name: "invalid expected status",
+			configString: `checks:
+  - name: health
+    url: http://example.com/health
+    expectedStatus: 42`,
+			wantErr: "check 'health' has an invalid expected status 42",
+		},
+		{
+			name: "invalid body regex",
+			configString: `checks:
+  - name: health
+    url: http://example.com/health
+    bodyRegex: '(UP'`,
+			wantErr: "check 'health' has an invalid body regex",
+		},
+		{
+			name: "invalid max latency",
+			configString: `checks:
+  - name: health
+    url: http://example.com/health
+    maxLatency: fast`,
+			wantErr: "check 'health' has an invalid max latency 'fast'",
+		},
+		{
+			name: "duplicate check names",
+			configString: `checks:
+  - name: health
+    url: http://example.com/health
+  - name: health
+    url: http://example.com/status`,
+			wantErr: "check 'health' is declared more than once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configGetter := NewSyntheticChecksConfigGetter(&syntheticChecksResourceClientMock{configString: tt.configString})
+			checksConfig, err := configGetter.GetSyntheticChecksConfig(&mockEvent)
+
+			if tt.wantErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.wantErr)
+				}
+				assert.Nil(t, checksConfig)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.EqualValues(t, tt.wantConfig, checksConfig)
+		})
+	}
+}
+
+type syntheticChecksResourceClientMock struct {
+	configString string
+}
+
+func (c *syntheticChecksResourceClientMock) GetSyntheticChecks(project string, stage string, service string) (string, error) {
+	return c.configString, nil
+}
@@ -0,0 +1,129 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+)
+
+const defaultSyntheticMonitorFrequency = 15
+
+var validSyntheticMonitorFrequencies = []int{0, 1, 2, 5, 10, 15, 30, 60}
+
+var validSyntheticAssertionTypes = []string{"httpStatusesList", "patternConstraint", "regexConstraint", "certificateExpiryDateConstraint"}
+
+// SyntheticMonitorsConfig defines the structure of the synthetic.yaml declaring the HTTP monitors of a service
+type SyntheticMonitorsConfig struct {
+	SpecVersion string `json:"spec_version" yaml:"spec_version"`
+
+	// Prune deletes monitors previously created for the same project, stage and service which are no longer declared
+	Prune    bool                          `json:"prune,omitempty" yaml:"prune,omitempty"`
+	Monitors []SyntheticMonitorDeclaration `json:"monitors,omitempty" yaml:"monitors,omitempty"`
+}
+
+// SyntheticMonitorDeclaration declares an HTTP monitor. Monitors are identified by their name.
+type SyntheticMonitorDeclaration struct {
+	Name string `json:"name" yaml:"name"`
+
+	// Frequency is the interval of scheduled executions in minutes, 0 disables scheduled executions. Defaults to 15.
+	Frequency *int                          `json:"frequency,omitempty" yaml:"frequency,omitempty"`
+	Locations []string                      `json:"locations,omitempty" yaml:"locations,omitempty"`
+	Tags      []string                      `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Requests  []SyntheticRequestDeclaration `json:"requests,omitempty" yaml:"requests,omitempty"`
+}
+
+// SyntheticRequestDeclaration declares a single request of an HTTP monitor
+type SyntheticRequestDeclaration struct {
+	Description string                   `json:"description,omitempty" yaml:"description,omitempty"`
+	Url         string                   `json:"url" yaml:"url"`
+	Method      string                   `json:"method,omitempty" yaml:"method,omitempty"`
+	Headers     []SyntheticRequestHeader `json:"headers,omitempty" yaml:"headers,omitempty"`
+	Body        string                   `json:"body,omitempty" yaml:"body,omitempty"`
+	Assertions  []SyntheticAssertion     `json:"assertions,omitempty" yaml:"assertions,omitempty"`
+}
+
+// SyntheticAssertion declares a validation rule of a request, e.g. type "httpStatusesList" with value ">=400" and passIfFound false.
+type SyntheticAssertion struct {
+	Type        string `json:"type" yaml:"type"`
+	Value       string `json:"value" yaml:"value"`
+	PassIfFound bool   `json:"passIfFound,omitempty" yaml:"passIfFound,omitempty"`
+}
+
+// GetFrequency returns the frequency of the monitor or the default frequency if none is defined.
+func (d SyntheticMonitorDeclaration) GetFrequency() int {
+	if d.Frequency == nil {
+		return defaultSyntheticMonitorFrequency
+	}
+
+	return *d.Frequency
+}
+
+// Validate checks that the monitor declaration is complete and uses supported values.
+func (d SyntheticMonitorDeclaration) Validate() error {
+	if d.Name == "" {
+		return errors.New("monitor has no name")
+	}
+
+	if !containsInt(validSyntheticMonitorFrequencies, d.GetFrequency()) {
+		return fmt.Errorf("monitor '%s' has an invalid frequency %d, must be one of %v", d.Name, d.GetFrequency(), validSyntheticMonitorFrequencies)
+	}
+
+	if len(d.Locations) == 0 {
+		return fmt.Errorf("monitor '%s' has no locations", d.Name)
+	}
+
+	if len(d.Requests) == 0 {
+		return fmt.Errorf("monitor '%s' has no requests", d.Name)
+	}
+
+	for i, request := range d.Requests {
+		if request.Url == "" {
+			return fmt.Errorf("request %d of monitor '%s' has no url", i+1, d.Name)
+		}
+
+		for _, assertion := range request.Assertions {
+			if !containsString(validSyntheticAssertionTypes, assertion.Type) {
+				return fmt.Errorf("request %d of monitor '%s' has an invalid assertion type '%s', must be one of %v", i+1, d.Name, assertion.Type, validSyntheticAssertionTypes)
+			}
+		}
+	}
+
+	return nil
+}
+
+// Validate checks that all monitors are valid and that their names are unique.
+func (c SyntheticMonitorsConfig) Validate() error {
+	names := make(map[string]bool, len(c.Monitors))
+	for _, monitor := range c.Monitors {
+		err := monitor.Validate()
+		if err != nil {
+			return err
+		}
+
+		if names[monitor.Name] {
+			return fmt.Errorf("monitor '%s' is declared more than once", monitor.Name)
+		}
+		names[monitor.Name] = true
+	}
+
+	return nil
+}
+
+func containsInt(values []int, value int) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,114 @@
+package config
+
+import (
+	"fmt"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+
+	"gopkg.in/yaml.v2"
+)
+
+type SyntheticMonitorsConfigProvider interface {
+	GetSyntheticMonitorsConfig(event adapter.EventContentAdapter) (*SyntheticMonitorsConfig, error)
+}
+
+type SyntheticMonitorsConfigGetter struct {
+	resourceClient keptn.SyntheticMonitorsReaderInterface
+}
+
+func NewSyntheticMonitorsConfigGetter(client keptn.SyntheticMonitorsReaderInterface) *SyntheticMonitorsConfigGetter {
+	return &SyntheticMonitorsConfigGetter{
+		resourceClient: client,
+	}
+}
+
+// GetSyntheticMonitorsConfig loads the synthetic.yaml from the GIT repo
+func (g *SyntheticMonitorsConfigGetter) GetSyntheticMonitorsConfig(event adapter.EventContentAdapter) (*SyntheticMonitorsConfig, error) {
+	fileContent, err := g.resourceClient.GetSyntheticMonitors(event.GetProject(), event.GetStage(), event.GetService())
+	if err != nil {
+		return nil, err
+	}
+
+	monitorsConfig, err := parseSyntheticMonitorsYAML(fileContent)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse synthetic monitors file found for service %s in stage %s in project %s: %s", event.GetService(), event.GetStage(), event.GetProject(), err.Error())
+	}
+
+	monitorsConfig = replacePlaceholdersInSyntheticMonitorsConfig(monitorsConfig, event)
+
+	err = monitorsConfig.Validate()
+	if err != nil {
+		return nil, fmt.Errorf("invalid synthetic monitors file found for service %s in stage %s in project %s: %s", event.GetService(), event.GetStage(), event.GetProject(), err.Error())
+	}
+
+	return monitorsConfig, nil
+}
+
+func replacePlaceholdersInSyntheticMonitorsConfig(monitorsConfig *SyntheticMonitorsConfig, event adapter.EventContentAdapter) *SyntheticMonitorsConfig {
+	monitorsWithReplacedPlaceholders := make([]SyntheticMonitorDeclaration, 0, len(monitorsConfig.Monitors))
+	for _, monitor := range monitorsConfig.Monitors {
+		monitorsWithReplacedPlaceholders = append(monitorsWithReplacedPlaceholders, ReplacePlaceholdersInSyntheticMonitorDeclaration(monitor, event))
+	}
+
+	return &SyntheticMonitorsConfig{
+		SpecVersion: monitorsConfig.SpecVersion,
+		Prune:       monitorsConfig.Prune,
+		Monitors:    monitorsWithReplacedPlaceholders,
+	}
+}
+
+// ReplacePlaceholdersInSyntheticMonitorDeclaration replaces the Keptn placeholders in the name, tags, locations and requests of the monitor declaration.
+func ReplacePlaceholdersInSyntheticMonitorDeclaration(monitor SyntheticMonitorDeclaration, event adapter.EventContentAdapter) SyntheticMonitorDeclaration {
+	requestsWithReplacedPlaceholders := make([]SyntheticRequestDeclaration, 0, len(monitor.Requests))
+	for _, request := range monitor.Requests {
+		var headersWithReplacedPlaceholders []SyntheticRequestHeader
+		for _, header := range request.Headers {
+			headersWithReplacedPlaceholders = append(headersWithReplacedPlaceholders, SyntheticRequestHeader{
+				Name:  header.Name,
+				Value: common.ReplaceKeptnPlaceholders(header.Value, event),
+			})
+		}
+
+		requestsWithReplacedPlaceholders = append(requestsWithReplacedPlaceholders, SyntheticRequestDeclaration{
+			Description: common.ReplaceKeptnPlaceholders(request.Description, event),
+			Url:         common.ReplaceKeptnPlaceholders(request.Url, event),
+			Method:      request.Method,
+			Headers:     headersWithReplacedPlaceholders,
+			Body:        common.ReplaceKeptnPlaceholders(request.Body, event),
+			Assertions:  request.Assertions,
+		})
+	}
+
+	return SyntheticMonitorDeclaration{
+		Name:      common.ReplaceKeptnPlaceholders(monitor.Name, event),
+		Frequency: monitor.Frequency,
+		Locations: replacePlaceholdersInStrings(monitor.Locations, event),
+		Tags:      replacePlaceholdersInStrings(monitor.Tags, event),
+		Requests:  requestsWithReplacedPlaceholders,
+	}
+}
+
+func replacePlaceholdersInStrings(values []string, event adapter.EventContentAdapter) []string {
+	if values == nil {
+		return nil
+	}
+
+	valuesWithReplacedPlaceholders := make([]string, 0, len(values))
+	for _, value := range values {
+		valuesWithReplacedPlaceholders = append(valuesWithReplacedPlaceholders, common.ReplaceKeptnPlaceholders(value, event))
+	}
+
+	return valuesWithReplacedPlaceholders
+}
+
+func parseSyntheticMonitorsYAML(input string) (*SyntheticMonitorsConfig, error) {
+	monitorsConfig := &SyntheticMonitorsConfig{}
+	err := yaml.Unmarshal([]byte(input), monitorsConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return monitorsConfig, nil
+}
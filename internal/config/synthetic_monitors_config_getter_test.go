@@ -0,0 +1,166 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSyntheticMonitorsConfigGetter_GetSyntheticMonitorsConfig tests that the synthetic.yaml is parsed and validated and that placeholders are replaced.
+func TestSyntheticMonitorsConfigGetter_GetSyntheticMonitorsConfig(t *testing.T) {
+	frequency := 5
+
+	mockEvent := test.EventData{
+		Context: "01234567-0123-0123-0123-012345678901",
+		Event:   "sh.keptn.event.test.triggered",
+		Project: "myproject",
+		Stage:   "mystage",
+		Service: "myservice",
+		Labels: map[string]string{
+			"token": "secret",
+		},
+	}
+
+	tests := []struct {
+		name         string
+		configString string
+		wantConfig   *SyntheticMonitorsConfig
+		wantErr      string
+	}{
+		{
+			name: "monitors with placeholders",
+			configString: `---
+spec_version: '0.1.0'
+prune: true
+monitors:
+  - name: $SERVICE health check
+    frequency: 5
+    locations:
+      - GEOLOCATION-9999453BE4BDB3CD
+    tags:
+      - app:$PROJECT
+    requests:
+      - description: health
+        url: https://$SERVICE.$STAGE.example.com/health
+        headers:
+          - name: Authorization
+            value: Bearer $LABEL.token
+        assertions:
+          - type: httpStatusesList
+            value: ">=400"
+  - name: $SERVICE login
+    locations:
+      - GEOLOCATION-9999453BE4BDB3CD
+    requests:
+      - url: https://$SERVICE.$STAGE.example.com/login
+        method: POST
+        body: '{"user":"$SERVICE"}'`,
+			wantConfig: &SyntheticMonitorsConfig{
+				SpecVersion: "0.1.0",
+				Prune:       true,
+				Monitors: []SyntheticMonitorDeclaration{
+					{
+						Name:      "myservice health check",
+						Frequency: &frequency,
+						Locations: []string{"GEOLOCATION-9999453BE4BDB3CD"},
+						Tags:      []string{"app:myproject"},
+						Requests: []SyntheticRequestDeclaration{
+							{
+								Description: "health",
+								Url:         "https://myservice.mystage.example.com/health",
+								Headers:     []SyntheticRequestHeader{{Name: "Authorization", Value: "Bearer secret"}},
+								Assertions:  []SyntheticAssertion{{Type: "httpStatusesList", Value: ">=400"}},
+							},
+						},
+					},
+					{
+						Name:      "myservice login",
+						Locations: []string{"GEOLOCATION-9999453BE4BDB3CD"},
+						Requests: []SyntheticRequestDeclaration{
+							{
+								Url:    "https://myservice.mystage.example.com/login",
+								Method: "POST",
+								Body:   `{"user":"myservice"}`,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name:         "invalid YAML",
+			configString: `monitors: {`,
+			wantErr:      "failed to parse synthetic monitors file",
+		},
+		{
+			name: "monitor without locations",
+			configString: `monitors:
+  - name: health check
+    requests:
+      - url: https://example.com/health`,
+			wantErr: "monitor 'health check' has no locations",
+		},
+		{
+			name: "invalid frequency",
+			configString: `monitors:
+  - name: health check
+    frequency: 3
+    locations: [GEOLOCATION-9999453BE4BDB3CD]
+    requests:
+      - url: https://example.com/health`,
+			wantErr: "monitor 'health check' has an invalid frequency 3",
+		},
+		{
+			name: "invalid assertion type",
+			configString: `monitors:
+  - name: health check
+    locations: [GEOLOCATION-9999453BE4BDB3CD]
+    requests:
+      - url: https://example.com/health
+        assertions:
+          - type: statusCode
+            value: "200"`,
+			wantErr: "request 1 of monitor 'health check' has an invalid assertion type 'statusCode'",
+		},
+		{
+			name: "duplicate monitor names",
+			configString: `monitors:
+  - name: health check
+    locations: [GEOLOCATION-9999453BE4BDB3CD]
+    requests:
+      - url: https://example.com/health
+  - name: health check
+    locations: [GEOLOCATION-9999453BE4BDB3CD]
+    requests:
+      - url: https://example.com/status`,
+			wantErr: "monitor 'health check' is declared more than once",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configGetter := NewSyntheticMonitorsConfigGetter(&syntheticMonitorsResourceClientMock{configString: tt.configString})
+			monitorsConfig, err := configGetter.GetSyntheticMonitorsConfig(&mockEvent)
+
+			if tt.wantErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.wantErr)
+				}
+				assert.Nil(t, monitorsConfig)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.EqualValues(t, tt.wantConfig, monitorsConfig)
+		})
+	}
+}
+
+type syntheticMonitorsResourceClientMock struct {
+	configString string
+}
+
+func (c *syntheticMonitorsResourceClientMock) GetSyntheticMonitors(project string, stage string, service string) (string, error) {
+	return c.configString, nil
+}
@@ -78,3 +78,34 @@ func (ec *EntitiesClient) GetKeptnManagedServices(ctx context.Context) ([]Entity
 	}
 	return entities, nil
 }
+
+// GetByEntitySelector gets all entities matching the entity selector, e.g. type("SERVICE"),tag("keptn_service:carts").
+func (ec *EntitiesClient) GetByEntitySelector(ctx context.Context, entitySelector string) ([]Entity, error) {
+	queryParameters := newQueryParameters()
+	queryParameters.add(entitySelectorKey, entitySelector)
+
+	entities := []Entity{}
+	path := entitiesPath + "?" + queryParameters.encode()
+	for {
+		response, err := ec.Client.Get(ctx, path)
+		if err != nil {
+			return nil, err
+		}
+
+		entitiesResponse := &EntitiesResponse{}
+		err = json.Unmarshal(response, entitiesResponse)
+		if err != nil {
+			return nil, fmt.Errorf("could not deserialize EntitiesResponse: %v", err)
+		}
+
+		entities = append(entities, entitiesResponse.Entities...)
+		if entitiesResponse.NextPageKey == "" {
+			return entities, nil
+		}
+
+		// the next page key already includes all other query parameters
+		nextPageParameters := newQueryParameters()
+		nextPageParameters.add("nextPageKey", entitiesResponse.NextPageKey)
+		path = entitiesPath + "?" + nextPageParameters.encode()
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"testing"
 
 	"github.com/go-test/deep"
+	"github.com/stretchr/testify/assert"
 )
 
 func TestEntitiesClient_GetKeptnManagedServices(t *testing.T) {
@@ -114,3 +115,29 @@ func TestEntitiesClient_GetKeptnManagedServices(t *testing.T) {
 		})
 	}
 }
+
+func TestEntitiesClient_GetByEntitySelector(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, entitiesPath, r.URL.Path)
+
+		if r.URL.Query().Get("nextPageKey") == "page2" {
+			assert.Empty(t, r.URL.Query().Get(entitySelectorKey))
+			_, _ = w.Write([]byte(`{"totalCount":2,"pageSize":1,"entities":[{"entityId":"HTTP_CHECK-2","displayName":"health check"}]}`))
+			return
+		}
+
+		assert.Equal(t, `type("HTTP_CHECK"),tag("keptn_service:carts")`, r.URL.Query().Get(entitySelectorKey))
+		_, _ = w.Write([]byte(`{"totalCount":2,"pageSize":1,"nextPageKey":"page2","entities":[{"entityId":"HTTP_CHECK-1","displayName":"login"}]}`))
+	})
+
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	entities, err := NewEntitiesClient(dtClient).GetByEntitySelector(context.TODO(), `type("HTTP_CHECK"),tag("keptn_service:carts")`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []Entity{
+		{EntityID: "HTTP_CHECK-1", DisplayName: "login"},
+		{EntityID: "HTTP_CHECK-2", DisplayName: "health check"},
+	}, entities)
+}
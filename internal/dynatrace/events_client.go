@@ -81,7 +81,9 @@ type TagRule struct {
 
 // AttachRules defines a Dynatrace configuration structure
 type AttachRules struct {
-	TagRule []TagRule `json:"tagRule" yaml:"tagRule"`
+	// EntityIds are set by the service for events concerning specific entities, e.g. the triggered synthetic monitors, and cannot be configured
+	EntityIds []string  `json:"entityIds,omitempty" yaml:"-"`
+	TagRule   []TagRule `json:"tagRule" yaml:"tagRule"`
 }
 
 type EventsClient struct {
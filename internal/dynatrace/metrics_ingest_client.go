@@ -0,0 +1,230 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const metricsIngestPath = "/api/v2/metrics/ingest"
+
+// maxMetricLinesPerRequest is the maximum number of lines sent in a single ingest request
+const maxMetricLinesPerRequest = 1000
+
+const maxMetricKeyLength = 250
+const maxDimensionKeyLength = 100
+const maxDimensionValueLength = 250
+
+// metricKeyPattern matches metric keys consisting of sections separated by dots, each starting with a letter or an underscore
+var metricKeyPattern = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_-]*(\.[a-zA-Z_][a-zA-Z0-9_-]*)*$`)
+
+// dimensionKeyPattern matches dimension keys starting with a lowercase letter or an underscore
+var dimensionKeyPattern = regexp.MustCompile(`^[a-z_][a-z0-9_.:-]*$`)
+
+// MetricsIngestResponse represents the response from the Dynatrace metrics ingest endpoint
+type MetricsIngestResponse struct {
+	LinesOk      int `json:"linesOk"`
+	LinesInvalid int `json:"linesInvalid"`
+}
+
+// MetricLine is a single valid line of the Dynatrace metrics ingestion protocol. Use a MetricLineBuilder to create it.
+type MetricLine struct {
+	line string
+}
+
+// String returns the line in the metrics ingestion protocol
+func (l MetricLine) String() string {
+	return l.line
+}
+
+// MetricLineBuilder builds a MetricLine, validating the metric key and dimension keys and escaping dimension values.
+type MetricLineBuilder struct {
+	key        string
+	dimensions []string
+	payload    string
+	timestamp  *time.Time
+	err        error
+}
+
+// NewMetricLineBuilder creates a new MetricLineBuilder for the specified metric key
+func NewMetricLineBuilder(key string) *MetricLineBuilder {
+	b := &MetricLineBuilder{key: key}
+	if len(key) > maxMetricKeyLength || !metricKeyPattern.MatchString(key) {
+		b.err = fmt.Errorf("invalid metric key: %s", key)
+	}
+
+	return b
+}
+
+// Dimension adds a dimension to the line. Dimensions with empty values are omitted.
+func (b *MetricLineBuilder) Dimension(key string, value string) *MetricLineBuilder {
+	if b.err != nil || value == "" {
+		return b
+	}
+
+	if len(key) > maxDimensionKeyLength || !dimensionKeyPattern.MatchString(key) {
+		b.err = fmt.Errorf("invalid dimension key: %s", key)
+		return b
+	}
+
+	if len(value) > maxDimensionValueLength {
+		b.err = fmt.Errorf("value of dimension %s exceeds %d characters", key, maxDimensionValueLength)
+		return b
+	}
+
+	b.dimensions = append(b.dimensions, key+"="+escapeDimensionValue(value))
+	return b
+}
+
+// Gauge sets a gauge payload with a single value
+func (b *MetricLineBuilder) Gauge(value float64) *MetricLineBuilder {
+	return b.setPayload("gauge," + b.formatValue(value))
+}
+
+// Count sets a count payload with the specified delta
+func (b *MetricLineBuilder) Count(delta float64) *MetricLineBuilder {
+	return b.setPayload("count,delta=" + b.formatValue(delta))
+}
+
+// Summary sets a gauge payload summarizing count values by their minimum, maximum and sum
+func (b *MetricLineBuilder) Summary(min float64, max float64, sum float64, count int) *MetricLineBuilder {
+	if count < 1 {
+		b.setError(fmt.Errorf("summary of metric %s requires a positive count", b.key))
+		return b
+	}
+
+	if min > max {
+		b.setError(fmt.Errorf("summary of metric %s has a minimum greater than its maximum", b.key))
+		return b
+	}
+
+	return b.setPayload(fmt.Sprintf("gauge,min=%s,max=%s,sum=%s,count=%d", b.formatValue(min), b.formatValue(max), b.formatValue(sum), count))
+}
+
+// Timestamp sets the timestamp of the line. If not set, Dynatrace uses the time of ingestion.
+func (b *MetricLineBuilder) Timestamp(timestamp time.Time) *MetricLineBuilder {
+	b.timestamp = &timestamp
+	return b
+}
+
+// Build builds the MetricLine or returns the first error encountered while building it
+func (b *MetricLineBuilder) Build() (MetricLine, error) {
+	if b.err != nil {
+		return MetricLine{}, b.err
+	}
+
+	if b.payload == "" {
+		return MetricLine{}, fmt.Errorf("metric %s has no payload", b.key)
+	}
+
+	line := strings.Join(append([]string{b.key}, b.dimensions...), ",") + " " + b.payload
+	if b.timestamp != nil {
+		line += " " + strconv.FormatInt(b.timestamp.UnixNano()/int64(time.Millisecond), 10)
+	}
+
+	return MetricLine{line: line}, nil
+}
+
+func (b *MetricLineBuilder) setPayload(payload string) *MetricLineBuilder {
+	if b.payload != "" {
+		b.setError(fmt.Errorf("metric %s already has a payload", b.key))
+	}
+
+	b.payload = payload
+	return b
+}
+
+func (b *MetricLineBuilder) setError(err error) {
+	if b.err == nil {
+		b.err = err
+	}
+}
+
+func (b *MetricLineBuilder) formatValue(value float64) string {
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		b.setError(fmt.Errorf("metric %s has an invalid value: %f", b.key, value))
+	}
+
+	return strconv.FormatFloat(value, 'f', -1, 64)
+}
+
+// escapeDimensionValue quotes values containing spaces, commas, equals signs or quotes and escapes quotes and backslashes within them
+func escapeDimensionValue(value string) string {
+	if !strings.ContainsAny(value, " ,=\"\\") {
+		return value
+	}
+
+	return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(value) + `"`
+}
+
+// MetricsIngestClient is a client for ingesting custom metrics via the Dynatrace metrics ingest endpoint
+type MetricsIngestClient struct {
+	client ClientInterface
+}
+
+// NewMetricsIngestClient creates a new MetricsIngestClient
+func NewMetricsIngestClient(client ClientInterface) *MetricsIngestClient {
+	return &MetricsIngestClient{
+		client: client,
+	}
+}
+
+// Ingest ingests the lines in batches of at most maxMetricLinesPerRequest lines.
+// An error is returned if a request fails or Dynatrace reports invalid lines; the response then contains the results of all requests sent so far.
+func (mic *MetricsIngestClient) Ingest(ctx context.Context, lines []MetricLine) (MetricsIngestResponse, error) {
+	total := MetricsIngestResponse{}
+	for start := 0; start < len(lines); start += maxMetricLinesPerRequest {
+		end := start + maxMetricLinesPerRequest
+		if end > len(lines) {
+			end = len(lines)
+		}
+
+		response, err := mic.ingest(ctx, lines[start:end])
+		total.LinesOk += response.LinesOk
+		total.LinesInvalid += response.LinesInvalid
+		if err != nil {
+			return total, err
+		}
+	}
+
+	return total, nil
+}
+
+func (mic *MetricsIngestClient) ingest(ctx context.Context, lines []MetricLine) (MetricsIngestResponse, error) {
+	payload := make([]string, 0, len(lines))
+	for _, line := range lines {
+		payload = append(payload, line.String())
+	}
+
+	body, err := mic.client.PostTextPlain(ctx, metricsIngestPath, []byte(strings.Join(payload, "\n")))
+	if err != nil {
+		var apiErr *APIError
+		if errors.As(err, &apiErr) {
+			// invalid lines are reported with status 400 and the usual response body
+			response := MetricsIngestResponse{}
+			if json.Unmarshal(body, &response) == nil && response.LinesInvalid > 0 {
+				return response, fmt.Errorf("%d of %d metric lines are invalid: %v", response.LinesInvalid, len(lines), err)
+			}
+		}
+
+		return MetricsIngestResponse{}, fmt.Errorf("could not ingest metrics: %v", err)
+	}
+
+	response := MetricsIngestResponse{}
+	err = json.Unmarshal(body, &response)
+	if err != nil {
+		return MetricsIngestResponse{}, fmt.Errorf("could not deserialize MetricsIngestResponse: %v", err)
+	}
+
+	if response.LinesInvalid > 0 {
+		return response, fmt.Errorf("%d of %d metric lines are invalid", response.LinesInvalid, len(lines))
+	}
+
+	return response, nil
+}
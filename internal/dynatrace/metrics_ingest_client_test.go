@@ -0,0 +1,170 @@
+package dynatrace
+
+import (
+	"context"
+	"io/ioutil"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetricLineBuilder(t *testing.T) {
+	tests := []struct {
+		name     string
+		builder  *MetricLineBuilder
+		wantLine string
+		wantErr  string
+	}{
+		{
+			name:     "gauge with dimensions",
+			builder:  NewMetricLineBuilder("ca.synthetic.execution_success_rate").Dimension("ca.project.name", "sockshop").Dimension("dt.entity.http_check", "HTTP_CHECK-1").Gauge(66.67),
+			wantLine: "ca.synthetic.execution_success_rate,ca.project.name=sockshop,dt.entity.http_check=HTTP_CHECK-1 gauge,66.67",
+		},
+		{
+			name:     "dimension values are escaped",
+			builder:  NewMetricLineBuilder("my.metric").Dimension("name", `my "quoted", spaced=value\`).Gauge(1),
+			wantLine: `my.metric,name="my \"quoted\", spaced=value\\" gauge,1`,
+		},
+		{
+			name:     "empty dimension values are omitted",
+			builder:  NewMetricLineBuilder("my.metric").Dimension("name", "").Count(3),
+			wantLine: "my.metric count,delta=3",
+		},
+		{
+			name:     "summary with timestamp",
+			builder:  NewMetricLineBuilder("my.metric").Summary(1, 5.5, 10, 3).Timestamp(time.Unix(1640995200, 0)),
+			wantLine: "my.metric gauge,min=1,max=5.5,sum=10,count=3 1640995200000",
+		},
+		{
+			name:    "invalid metric key",
+			builder: NewMetricLineBuilder("my metric").Gauge(1),
+			wantErr: "invalid metric key: my metric",
+		},
+		{
+			name:    "metric key section starting with a digit",
+			builder: NewMetricLineBuilder("my.1metric").Gauge(1),
+			wantErr: "invalid metric key: my.1metric",
+		},
+		{
+			name:    "invalid dimension key",
+			builder: NewMetricLineBuilder("my.metric").Dimension("Project Name", "sockshop").Gauge(1),
+			wantErr: "invalid dimension key: Project Name",
+		},
+		{
+			name:    "dimension value too long",
+			builder: NewMetricLineBuilder("my.metric").Dimension("name", strings.Repeat("a", 251)).Gauge(1),
+			wantErr: "value of dimension name exceeds 250 characters",
+		},
+		{
+			name:    "missing payload",
+			builder: NewMetricLineBuilder("my.metric"),
+			wantErr: "metric my.metric has no payload",
+		},
+		{
+			name:    "multiple payloads",
+			builder: NewMetricLineBuilder("my.metric").Gauge(1).Count(1),
+			wantErr: "metric my.metric already has a payload",
+		},
+		{
+			name:    "invalid value",
+			builder: NewMetricLineBuilder("my.metric").Gauge(math.NaN()),
+			wantErr: "metric my.metric has an invalid value: NaN",
+		},
+		{
+			name:    "invalid summary",
+			builder: NewMetricLineBuilder("my.metric").Summary(5, 1, 6, 2),
+			wantErr: "summary of metric my.metric has a minimum greater than its maximum",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line, err := tt.builder.Build()
+			if tt.wantErr != "" {
+				assert.EqualError(t, err, tt.wantErr)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantLine, line.String())
+		})
+	}
+}
+
+func TestMetricsIngestClient_Ingest(t *testing.T) {
+	requestLineCounts := []int{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, metricsIngestPath, r.URL.Path)
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		lineCount := len(strings.Split(string(body), "\n"))
+		requestLineCounts = append(requestLineCounts, lineCount)
+
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"linesOk":` + strconv.Itoa(lineCount) + `,"linesInvalid":0}`))
+	})
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	lines := createMetricLines(t, 2500)
+
+	response, err := NewMetricsIngestClient(dtClient).Ingest(context.TODO(), lines)
+
+	assert.NoError(t, err)
+	assert.Equal(t, MetricsIngestResponse{LinesOk: 2500}, response)
+	assert.Equal(t, []int{1000, 1000, 500}, requestLineCounts)
+}
+
+func TestMetricsIngestClient_Ingest_InvalidLines(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		body    string
+		wantErr string
+	}{
+		{
+			name:    "partially accepted",
+			status:  http.StatusAccepted,
+			body:    `{"linesOk":1,"linesInvalid":1}`,
+			wantErr: "1 of 2 metric lines are invalid",
+		},
+		{
+			name:    "rejected",
+			status:  http.StatusBadRequest,
+			body:    `{"linesOk":0,"linesInvalid":2,"error":{"code":400,"message":"2 invalid lines"}}`,
+			wantErr: "2 of 2 metric lines are invalid",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte(tt.body))
+			})
+			dtClient, _, teardown := createDynatraceClient(t, handler)
+			defer teardown()
+
+			_, err := NewMetricsIngestClient(dtClient).Ingest(context.TODO(), createMetricLines(t, 2))
+
+			if assert.Error(t, err) {
+				assert.Contains(t, err.Error(), tt.wantErr)
+			}
+		})
+	}
+}
+
+func createMetricLines(t *testing.T, count int) []MetricLine {
+	lines := make([]MetricLine, 0, count)
+	for i := 0; i < count; i++ {
+		line, err := NewMetricLineBuilder("my.metric").Dimension("index", strconv.Itoa(i)).Gauge(float64(i)).Build()
+		assert.NoError(t, err)
+		lines = append(lines, line)
+	}
+
+	return lines
+}
@@ -0,0 +1,50 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const syntheticLocationsPath = "/api/v2/synthetic/locations"
+
+// SyntheticLocationsResponse represents the response from the Dynatrace synthetic locations endpoint
+type SyntheticLocationsResponse struct {
+	Locations []SyntheticLocation `json:"locations"`
+}
+
+// SyntheticLocation represents a public or private Dynatrace synthetic location
+type SyntheticLocation struct {
+	EntityID string `json:"entityId"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Status   string `json:"status"`
+}
+
+// SyntheticLocationsClient is a client for interacting with the Dynatrace synthetic locations endpoint
+type SyntheticLocationsClient struct {
+	client ClientInterface
+}
+
+// NewSyntheticLocationsClient creates a new SyntheticLocationsClient
+func NewSyntheticLocationsClient(client ClientInterface) *SyntheticLocationsClient {
+	return &SyntheticLocationsClient{
+		client: client,
+	}
+}
+
+// GetAll gets all public and private synthetic locations.
+func (slc *SyntheticLocationsClient) GetAll(ctx context.Context) ([]SyntheticLocation, error) {
+	response, err := slc.client.Get(ctx, syntheticLocationsPath)
+	if err != nil {
+		return nil, err
+	}
+
+	locationsResponse := &SyntheticLocationsResponse{}
+	err = json.Unmarshal(response, locationsResponse)
+	if err != nil {
+		return nil, fmt.Errorf("could not deserialize SyntheticLocationsResponse: %v", err)
+	}
+
+	return locationsResponse.Locations, nil
+}
@@ -0,0 +1,24 @@
+package dynatrace
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyntheticLocationsClient_GetAll(t *testing.T) {
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(syntheticLocationsPath, "./testdata/test_syntheticlocationsclient_getall.json")
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	locations, err := NewSyntheticLocationsClient(dtClient).GetAll(context.TODO())
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, []SyntheticLocation{
+		{EntityID: "GEOLOCATION-9999453BE4BDB3CD", Name: "N. Virginia (Amazon US East)", Type: "PUBLIC", Status: "ENABLED"},
+		{EntityID: "SYNTHETIC_LOCATION-0000000000000001", Name: "Internal ActiveGate", Type: "PRIVATE", Status: "ENABLED"},
+	}, locations)
+}
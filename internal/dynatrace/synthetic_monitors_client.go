@@ -0,0 +1,277 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const syntheticMonitorsPath = "/api/v1/synthetic/monitors"
+
+const tagKey = "tag"
+
+// SyntheticMonitorsResponse represents the response from the Dynatrace synthetic monitors endpoint
+type SyntheticMonitorsResponse struct {
+	Monitors []SyntheticMonitorSummary `json:"monitors"`
+}
+
+// SyntheticMonitorSummary represents the summary of a Dynatrace synthetic monitor
+type SyntheticMonitorSummary struct {
+	EntityID string `json:"entityId"`
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	Enabled  bool   `json:"enabled"`
+}
+
+// SyntheticMonitor represents a Dynatrace synthetic monitor including its script
+type SyntheticMonitor struct {
+	EntityID  string                 `json:"entityId"`
+	Name      string                 `json:"name"`
+	Type      string                 `json:"type"`
+	Enabled   bool                   `json:"enabled"`
+	Locations []string               `json:"locations"`
+	Script    SyntheticMonitorScript `json:"script"`
+}
+
+// SyntheticMonitorScript represents the script of a synthetic monitor, i.e. the requests of an HTTP monitor or the events of a browser monitor
+type SyntheticMonitorScript struct {
+	Requests []SyntheticMonitorRequest `json:"requests,omitempty"`
+	Events   []SyntheticMonitorEvent   `json:"events,omitempty"`
+}
+
+// SyntheticMonitorRequest represents a single request of an HTTP monitor
+type SyntheticMonitorRequest struct {
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url"`
+	Method      string `json:"method,omitempty"`
+}
+
+// SyntheticMonitorEvent represents a single event of a browser monitor. Only navigate events have a URL.
+type SyntheticMonitorEvent struct {
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	URL         string `json:"url,omitempty"`
+}
+
+// HTTPMonitorDefinition represents the definition of an HTTP monitor used for creating or updating it
+type HTTPMonitorDefinition struct {
+	Name             string                    `json:"name"`
+	FrequencyMin     int                       `json:"frequencyMin"`
+	Enabled          bool                      `json:"enabled"`
+	Type             string                    `json:"type"`
+	Script           HTTPMonitorScript         `json:"script"`
+	Locations        []string                  `json:"locations"`
+	AnomalyDetection SyntheticAnomalyDetection `json:"anomalyDetection"`
+	Tags             []SyntheticMonitorTag     `json:"tags,omitempty"`
+}
+
+// HTTPMonitorScript represents the script of an HTTP monitor
+type HTTPMonitorScript struct {
+	Version  string               `json:"version"`
+	Requests []HTTPMonitorRequest `json:"requests"`
+}
+
+// HTTPMonitorRequest represents a single request of an HTTP monitor script
+type HTTPMonitorRequest struct {
+	Description   string                          `json:"description,omitempty"`
+	URL           string                          `json:"url"`
+	Method        string                          `json:"method"`
+	RequestBody   string                          `json:"requestBody,omitempty"`
+	Configuration HTTPMonitorRequestConfiguration `json:"configuration"`
+	Validation    *HTTPMonitorRequestValidation   `json:"validation,omitempty"`
+}
+
+// HTTPMonitorRequestConfiguration represents the configuration of a single request of an HTTP monitor script
+type HTTPMonitorRequestConfiguration struct {
+	AcceptAnyCertificate bool                       `json:"acceptAnyCertificate"`
+	FollowRedirects      bool                       `json:"followRedirects"`
+	RequestHeaders       []HTTPMonitorRequestHeader `json:"requestHeaders,omitempty"`
+}
+
+// HTTPMonitorRequestHeader represents an HTTP header sent with a request of an HTTP monitor
+type HTTPMonitorRequestHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// HTTPMonitorRequestValidation represents the validation rules of a request of an HTTP monitor
+type HTTPMonitorRequestValidation struct {
+	Rules         []HTTPMonitorValidationRule `json:"rules"`
+	RulesChaining string                      `json:"rulesChaining"`
+}
+
+// HTTPMonitorValidationRule represents a validation rule, e.g. of type "httpStatusesList" or "patternConstraint"
+type HTTPMonitorValidationRule struct {
+	Type        string `json:"type"`
+	Value       string `json:"value"`
+	PassIfFound bool   `json:"passIfFound"`
+}
+
+// SyntheticAnomalyDetection represents the anomaly detection settings of a synthetic monitor
+type SyntheticAnomalyDetection struct {
+	OutageHandling        SyntheticOutageHandling        `json:"outageHandling"`
+	LoadingTimeThresholds SyntheticLoadingTimeThresholds `json:"loadingTimeThresholds"`
+}
+
+// SyntheticOutageHandling represents the outage handling settings of a synthetic monitor
+type SyntheticOutageHandling struct {
+	GlobalOutage      bool                       `json:"globalOutage"`
+	LocalOutage       bool                       `json:"localOutage"`
+	LocalOutagePolicy SyntheticLocalOutagePolicy `json:"localOutagePolicy"`
+}
+
+// SyntheticLocalOutagePolicy represents the conditions raising a local outage of a synthetic monitor
+type SyntheticLocalOutagePolicy struct {
+	AffectedLocations int `json:"affectedLocations"`
+	ConsecutiveRuns   int `json:"consecutiveRuns"`
+}
+
+// SyntheticLoadingTimeThresholds represents the performance thresholds of a synthetic monitor
+type SyntheticLoadingTimeThresholds struct {
+	Enabled    bool          `json:"enabled"`
+	Thresholds []interface{} `json:"thresholds"`
+}
+
+// SyntheticMonitorTag represents a tag of a synthetic monitor
+type SyntheticMonitorTag struct {
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+}
+
+// SyntheticMonitorEntityID represents the response from the Dynatrace synthetic monitors endpoint when creating a monitor
+type SyntheticMonitorEntityID struct {
+	EntityID string `json:"entityId"`
+}
+
+// SyntheticMonitorsClient is a client for interacting with the Dynatrace synthetic monitors endpoints
+type SyntheticMonitorsClient struct {
+	client ClientInterface
+}
+
+// NewSyntheticMonitorsClient creates a new SyntheticMonitorsClient
+func NewSyntheticMonitorsClient(client ClientInterface) *SyntheticMonitorsClient {
+	return &SyntheticMonitorsClient{
+		client: client,
+	}
+}
+
+// GetAll gets all synthetic monitors.
+func (smc *SyntheticMonitorsClient) GetAll(ctx context.Context) ([]SyntheticMonitorSummary, error) {
+	return smc.getMonitors(ctx, syntheticMonitorsPath)
+}
+
+// GetByTags gets all synthetic monitors having all the specified tags, e.g. "key" or "key:value".
+func (smc *SyntheticMonitorsClient) GetByTags(ctx context.Context, tags []string) ([]SyntheticMonitorSummary, error) {
+	queryParameters := newQueryParameters()
+	for _, tag := range tags {
+		queryParameters.add(tagKey, tag)
+	}
+
+	return smc.getMonitors(ctx, syntheticMonitorsPath+"?"+queryParameters.encode())
+}
+
+func (smc *SyntheticMonitorsClient) getMonitors(ctx context.Context, apiPath string) ([]SyntheticMonitorSummary, error) {
+	response, err := smc.client.Get(ctx, apiPath)
+	if err != nil {
+		return nil, err
+	}
+
+	monitorsResponse := &SyntheticMonitorsResponse{}
+	err = json.Unmarshal(response, monitorsResponse)
+	if err != nil {
+		return nil, fmt.Errorf("could not deserialize SyntheticMonitorsResponse: %v", err)
+	}
+
+	return monitorsResponse.Monitors, nil
+}
+
+// GetByID gets the synthetic monitor with the specified id, including its script.
+func (smc *SyntheticMonitorsClient) GetByID(ctx context.Context, monitorID string) (*SyntheticMonitor, error) {
+	response, err := smc.client.Get(ctx, syntheticMonitorsPath+"/"+monitorID)
+	if err != nil {
+		return nil, err
+	}
+
+	monitor := &SyntheticMonitor{}
+	err = json.Unmarshal(response, monitor)
+	if err != nil {
+		return nil, fmt.Errorf("could not deserialize SyntheticMonitor: %v", err)
+	}
+
+	return monitor, nil
+}
+
+// CreateHTTPMonitor creates an HTTP monitor and returns its id.
+func (smc *SyntheticMonitorsClient) CreateHTTPMonitor(ctx context.Context, monitor HTTPMonitorDefinition) (string, error) {
+	payload, err := json.Marshal(monitor)
+	if err != nil {
+		return "", fmt.Errorf("could not marshal HTTP monitor: %v", err)
+	}
+
+	response, err := smc.client.Post(ctx, syntheticMonitorsPath, payload)
+	if err != nil {
+		return "", fmt.Errorf("could not create HTTP monitor '%s': %v", monitor.Name, err)
+	}
+
+	entityID := &SyntheticMonitorEntityID{}
+	err = json.Unmarshal(response, entityID)
+	if err != nil {
+		return "", fmt.Errorf("could not deserialize SyntheticMonitorEntityID: %v", err)
+	}
+
+	return entityID.EntityID, nil
+}
+
+// UpdateHTTPMonitor replaces the definition of the HTTP monitor with the specified id.
+func (smc *SyntheticMonitorsClient) UpdateHTTPMonitor(ctx context.Context, monitorID string, monitor HTTPMonitorDefinition) error {
+	payload, err := json.Marshal(monitor)
+	if err != nil {
+		return fmt.Errorf("could not marshal HTTP monitor: %v", err)
+	}
+
+	_, err = smc.client.Put(ctx, syntheticMonitorsPath+"/"+monitorID, payload)
+	if err != nil {
+		return fmt.Errorf("could not update HTTP monitor %s: %v", monitorID, err)
+	}
+
+	return nil
+}
+
+// SetEnabled enables or disables the synthetic monitor with the specified id, leaving all other settings unchanged.
+func (smc *SyntheticMonitorsClient) SetEnabled(ctx context.Context, monitorID string, enabled bool) error {
+	response, err := smc.client.Get(ctx, syntheticMonitorsPath+"/"+monitorID)
+	if err != nil {
+		return err
+	}
+
+	// the monitor is updated as raw JSON so that settings not modeled by SyntheticMonitor are retained
+	monitor := map[string]interface{}{}
+	err = json.Unmarshal(response, &monitor)
+	if err != nil {
+		return fmt.Errorf("could not deserialize SyntheticMonitor: %v", err)
+	}
+
+	monitor["enabled"] = enabled
+
+	payload, err := json.Marshal(monitor)
+	if err != nil {
+		return fmt.Errorf("could not marshal synthetic monitor: %v", err)
+	}
+
+	_, err = smc.client.Put(ctx, syntheticMonitorsPath+"/"+monitorID, payload)
+	if err != nil {
+		return fmt.Errorf("could not update synthetic monitor %s: %v", monitorID, err)
+	}
+
+	return nil
+}
+
+// DeleteByID deletes the synthetic monitor with the specified id.
+func (smc *SyntheticMonitorsClient) DeleteByID(ctx context.Context, monitorID string) error {
+	_, err := smc.client.Delete(ctx, syntheticMonitorsPath+"/"+monitorID)
+	if err != nil {
+		return fmt.Errorf("could not delete synthetic monitor %s: %v", monitorID, err)
+	}
+
+	return nil
+}
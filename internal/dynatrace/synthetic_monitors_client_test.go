@@ -0,0 +1,153 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSyntheticMonitorsClient_GetByTags(t *testing.T) {
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(syntheticMonitorsPath+"?tag=app%3Aeasytravel&tag=smoke", "./testdata/test_syntheticmonitorsclient_getbytags.json")
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	monitors, err := NewSyntheticMonitorsClient(dtClient).GetByTags(context.TODO(), []string{"app:easytravel", "smoke"})
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, []SyntheticMonitorSummary{
+		{EntityID: "HTTP_CHECK-0000000000000001", Name: "easytravel health check", Type: "HTTP", Enabled: true},
+		{EntityID: "SYNTHETIC_TEST-0000000000000002", Name: "easytravel booking", Type: "BROWSER", Enabled: false},
+	}, monitors)
+}
+
+func TestSyntheticMonitorsClient_GetByID(t *testing.T) {
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact(syntheticMonitorsPath+"/HTTP_CHECK-0000000000000001", "./testdata/test_syntheticmonitorsclient_getbyid.json")
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	monitor, err := NewSyntheticMonitorsClient(dtClient).GetByID(context.TODO(), "HTTP_CHECK-0000000000000001")
+
+	assert.NoError(t, err)
+	assert.EqualValues(t, &SyntheticMonitor{
+		EntityID:  "HTTP_CHECK-0000000000000001",
+		Name:      "easytravel health check",
+		Type:      "HTTP",
+		Enabled:   true,
+		Locations: []string{"GEOLOCATION-9999453BE4BDB3CD"},
+		Script: SyntheticMonitorScript{
+			Requests: []SyntheticMonitorRequest{
+				{Description: "health", URL: "https://easytravel.example.com/health", Method: "GET"},
+				{Description: "login", URL: "https://easytravel.example.com/api/login?user=test", Method: "POST"},
+			},
+		},
+	}, monitor)
+}
+
+func TestSyntheticMonitorsClient_CreateHTTPMonitor(t *testing.T) {
+	monitor := HTTPMonitorDefinition{
+		Name:         "easytravel health check",
+		FrequencyMin: 15,
+		Enabled:      true,
+		Type:         "HTTP",
+		Script: HTTPMonitorScript{
+			Version: "1.0",
+			Requests: []HTTPMonitorRequest{
+				{URL: "https://easytravel.example.com/health", Method: "GET"},
+			},
+		},
+		Locations: []string{"GEOLOCATION-9999453BE4BDB3CD"},
+		Tags:      []SyntheticMonitorTag{{Key: "keptn_project", Value: "easytravel"}},
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, syntheticMonitorsPath, r.URL.Path)
+
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		receivedMonitor := HTTPMonitorDefinition{}
+		assert.NoError(t, json.Unmarshal(body, &receivedMonitor))
+		assert.EqualValues(t, monitor, receivedMonitor)
+
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"entityId":"HTTP_CHECK-0000000000000001","name":"easytravel health check"}`))
+	})
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	monitorID, err := NewSyntheticMonitorsClient(dtClient).CreateHTTPMonitor(context.TODO(), monitor)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "HTTP_CHECK-0000000000000001", monitorID)
+}
+
+func TestSyntheticMonitorsClient_UpdateHTTPMonitor(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPut, r.Method)
+		assert.Equal(t, syntheticMonitorsPath+"/HTTP_CHECK-0000000000000001", r.URL.Path)
+		w.WriteHeader(http.StatusNoContent)
+	})
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	err := NewSyntheticMonitorsClient(dtClient).UpdateHTTPMonitor(context.TODO(), "HTTP_CHECK-0000000000000001", HTTPMonitorDefinition{Name: "easytravel health check"})
+
+	assert.NoError(t, err)
+}
+
+func TestSyntheticMonitorsClient_SetEnabled(t *testing.T) {
+	var updatedMonitor map[string]interface{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, syntheticMonitorsPath+"/HTTP_CHECK-0000000000000001", r.URL.Path)
+
+		switch r.Method {
+		case http.MethodGet:
+			_, _ = w.Write([]byte(`{"entityId":"HTTP_CHECK-0000000000000001","name":"easytravel health check","enabled":false,"frequencyMin":15,"manuallyAssignedApps":["APPLICATION-1"]}`))
+		case http.MethodPut:
+			body, err := ioutil.ReadAll(r.Body)
+			assert.NoError(t, err)
+			assert.NoError(t, json.Unmarshal(body, &updatedMonitor))
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected method %s", r.Method)
+		}
+	})
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	err := NewSyntheticMonitorsClient(dtClient).SetEnabled(context.TODO(), "HTTP_CHECK-0000000000000001", true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"entityId":             "HTTP_CHECK-0000000000000001",
+		"name":                 "easytravel health check",
+		"enabled":              true,
+		"frequencyMin":         float64(15),
+		"manuallyAssignedApps": []interface{}{"APPLICATION-1"},
+	}, updatedMonitor)
+}
+
+func TestSyntheticMonitorsClient_DeleteByID(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodDelete, r.Method)
+		assert.Equal(t, syntheticMonitorsPath+"/HTTP_CHECK-0000000000000001", r.URL.Path)
+		w.WriteHeader(http.StatusNotFound)
+		_, _ = w.Write([]byte(`{"error":{"code":404,"message":"Monitor not found"}}`))
+	})
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	err := NewSyntheticMonitorsClient(dtClient).DeleteByID(context.TODO(), "HTTP_CHECK-0000000000000001")
+
+	if assert.Error(t, err) {
+		assert.Contains(t, err.Error(), "could not delete synthetic monitor HTTP_CHECK-0000000000000001")
+	}
+}
@@ -0,0 +1,109 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const thirdPartySyntheticTestsPath = "/api/v1/synthetic/ext/tests"
+
+// ThirdPartySyntheticTests represents third-party synthetic monitors and their results as pushed to Dynatrace
+type ThirdPartySyntheticTests struct {
+	MessageTimestamp       int64                         `json:"messageTimestamp"`
+	SyntheticEngineName    string                        `json:"syntheticEngineName"`
+	SyntheticEngineIconURL string                        `json:"syntheticEngineIconUrl,omitempty"`
+	Locations              []ThirdPartySyntheticLocation `json:"locations"`
+	Tests                  []ThirdPartySyntheticMonitor  `json:"tests"`
+	TestResults            []ThirdPartySyntheticResult   `json:"testResults,omitempty"`
+}
+
+// ThirdPartySyntheticLocation represents a location third-party synthetic monitors are executed from
+type ThirdPartySyntheticLocation struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// ThirdPartySyntheticMonitor represents the definition of a third-party synthetic monitor
+type ThirdPartySyntheticMonitor struct {
+	ID                        string                               `json:"id"`
+	Title                     string                               `json:"title"`
+	Description               string                               `json:"description,omitempty"`
+	TestSetup                 string                               `json:"testSetup"`
+	DrilldownLink             string                               `json:"drilldownLink,omitempty"`
+	Enabled                   bool                                 `json:"enabled"`
+	Deleted                   bool                                 `json:"deleted"`
+	Locations                 []ThirdPartySyntheticMonitorLocation `json:"locations"`
+	Steps                     []ThirdPartySyntheticMonitorStep     `json:"steps"`
+	ScheduleIntervalInSeconds int                                  `json:"scheduleIntervalInSeconds"`
+}
+
+// ThirdPartySyntheticMonitorLocation represents a location a third-party synthetic monitor is executed from
+type ThirdPartySyntheticMonitorLocation struct {
+	ID      string `json:"id"`
+	Enabled bool   `json:"enabled"`
+}
+
+// ThirdPartySyntheticMonitorStep represents a step of a third-party synthetic monitor
+type ThirdPartySyntheticMonitorStep struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+}
+
+// ThirdPartySyntheticResult represents the results of a third-party synthetic monitor
+type ThirdPartySyntheticResult struct {
+	ID                        string                              `json:"id"`
+	ScheduleIntervalInSeconds int                                 `json:"scheduleIntervalInSeconds"`
+	TotalStepCount            int                                 `json:"totalStepCount"`
+	LocationResults           []ThirdPartySyntheticLocationResult `json:"locationResults"`
+}
+
+// ThirdPartySyntheticLocationResult represents the result of a third-party synthetic monitor at a location
+type ThirdPartySyntheticLocationResult struct {
+	ID                 string                          `json:"id"`
+	StartTimestamp     int64                           `json:"startTimestamp"`
+	Success            bool                            `json:"success"`
+	ResponseTimeMillis int64                           `json:"responseTimeMillis"`
+	StepResults        []ThirdPartySyntheticStepResult `json:"stepResults"`
+}
+
+// ThirdPartySyntheticStepResult represents the result of a single step of a third-party synthetic monitor
+type ThirdPartySyntheticStepResult struct {
+	ID                 int                       `json:"id"`
+	StartTimestamp     int64                     `json:"startTimestamp"`
+	ResponseTimeMillis int64                     `json:"responseTimeMillis"`
+	Error              *ThirdPartySyntheticError `json:"error,omitempty"`
+}
+
+// ThirdPartySyntheticError represents the error of a failed step of a third-party synthetic monitor
+type ThirdPartySyntheticError struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// ThirdPartySyntheticClient is a client for pushing third-party synthetic monitors and their results.
+type ThirdPartySyntheticClient struct {
+	client ClientInterface
+}
+
+// NewThirdPartySyntheticClient creates a new ThirdPartySyntheticClient
+func NewThirdPartySyntheticClient(client ClientInterface) *ThirdPartySyntheticClient {
+	return &ThirdPartySyntheticClient{
+		client: client,
+	}
+}
+
+// Push creates or updates the third-party synthetic monitors and adds their results.
+func (c *ThirdPartySyntheticClient) Push(ctx context.Context, tests ThirdPartySyntheticTests) error {
+	payload, err := json.Marshal(tests)
+	if err != nil {
+		return fmt.Errorf("could not marshal third-party synthetic tests: %v", err)
+	}
+
+	_, err = c.client.Post(ctx, thirdPartySyntheticTestsPath, payload)
+	if err != nil {
+		return fmt.Errorf("could not push third-party synthetic tests of engine '%s': %v", tests.SyntheticEngineName, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,67 @@
+package dynatrace
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThirdPartySyntheticClient_Push(t *testing.T) {
+	tests := ThirdPartySyntheticTests{
+		MessageTimestamp:    1650016800000,
+		SyntheticEngineName: "k6",
+		Locations:           []ThirdPartySyntheticLocation{{ID: "keptn", Name: "Keptn"}},
+		Tests: []ThirdPartySyntheticMonitor{
+			{
+				ID:                        "keptn-easytravel-staging-frontend-k6-checkout",
+				Title:                     "checkout",
+				TestSetup:                 "k6",
+				Enabled:                   true,
+				Locations:                 []ThirdPartySyntheticMonitorLocation{{ID: "keptn", Enabled: true}},
+				Steps:                     []ThirdPartySyntheticMonitorStep{{ID: 1, Title: "status is 200"}},
+				ScheduleIntervalInSeconds: 3600,
+			},
+		},
+		TestResults: []ThirdPartySyntheticResult{
+			{
+				ID:                        "keptn-easytravel-staging-frontend-k6-checkout",
+				ScheduleIntervalInSeconds: 3600,
+				TotalStepCount:            1,
+				LocationResults: []ThirdPartySyntheticLocationResult{
+					{
+						ID:                 "keptn",
+						StartTimestamp:     1650016800000,
+						ResponseTimeMillis: 250,
+						StepResults: []ThirdPartySyntheticStepResult{
+							{ID: 1, StartTimestamp: 1650016800000, ResponseTimeMillis: 250, Error: &ThirdPartySyntheticError{Code: 1, Message: "2 of 10 checks failed"}},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, thirdPartySyntheticTestsPath, r.URL.Path)
+
+		body, err := ioutil.ReadAll(r.Body)
+		assert.NoError(t, err)
+
+		receivedTests := ThirdPartySyntheticTests{}
+		assert.NoError(t, json.Unmarshal(body, &receivedTests))
+		assert.EqualValues(t, tests, receivedTests)
+
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	dtClient, _, teardown := createDynatraceClient(t, handler)
+	defer teardown()
+
+	err := NewThirdPartySyntheticClient(dtClient).Push(context.TODO(), tests)
+	assert.NoError(t, err)
+}
@@ -3,6 +3,7 @@ package env
 import (
 	"os"
 	"strconv"
+	"strings"
 	"time"
 
 	log "github.com/sirupsen/logrus"
@@ -96,6 +97,30 @@ func GetServiceSyncInterval() int {
 	return readEnvAsInt("SYNCHRONIZE_DYNATRACE_SERVICES_INTERVAL_SECONDS", 60)
 }
 
+// GetSyntheticBatchStateStore returns the store the state of running synthetic batches is persisted to, i.e. "file" or "configmap".
+// If the environment variable is empty, the state is not persisted and running batches are not resumed after a restart.
+func GetSyntheticBatchStateStore() string {
+	return strings.ToLower(os.Getenv("SYNTHETIC_BATCH_STATE_STORE"))
+}
+
+// GetSyntheticBatchStateDirectory returns the directory the state of running synthetic batches is persisted to by the file store.
+func GetSyntheticBatchStateDirectory() string {
+	directory := os.Getenv("SYNTHETIC_BATCH_STATE_DIRECTORY")
+	if directory == "" {
+		return "/var/lib/dynatrace-service/batches"
+	}
+	return directory
+}
+
+// GetSyntheticBatchStateConfigMap returns the name of the ConfigMap the state of running synthetic batches is persisted to by the ConfigMap store.
+func GetSyntheticBatchStateConfigMap() string {
+	configMap := os.Getenv("SYNTHETIC_BATCH_STATE_CONFIGMAP")
+	if configMap == "" {
+		return "dynatrace-synthetic-service-batches"
+	}
+	return configMap
+}
+
 func readEnvAsBool(env string, defaultValue bool) bool {
 	envValue := os.Getenv(env)
 	if envValue == "" {
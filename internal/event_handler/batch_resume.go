@@ -0,0 +1,145 @@
+package event_handler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic"
+)
+
+// resumeRetryInterval is the initial interval between attempts to create the handler of a batch to resume, which is doubled after each attempt up to resumeMaxRetryInterval
+const resumeRetryInterval = 10 * time.Second
+const resumeMaxRetryInterval = 2 * time.Minute
+
+// resumeMaxAttempts limits the attempts to create the handler of a batch to resume, the state is kept afterwards, so that resuming is attempted again after the next restart
+const resumeMaxAttempts = 8
+
+// errNotSyntheticEvent is returned if the event of a persisted state is not handled by the synthetic test handler
+var errNotSyntheticEvent = errors.New("event is not handled by the synthetic test handler")
+
+// ResumeSyntheticBatch resumes waiting for the batch of a synthetic test with the specified persisted state and sends the finished event of the test.
+// Creating the handler is retried if it fails due to a transient error, e.g. if the configuration service is not reachable yet, and the state is kept if it still fails.
+// If the test can never be resumed, its monitors are cleaned up, an error is sent to Keptn and the state is deleted, so that resuming is not attempted again after the next restart.
+func ResumeSyntheticBatch(workCtx context.Context, replyCtx context.Context, batchStates synthetic.BatchStateStore, state synthetic.BatchState) error {
+	clientFactory := keptn.NewClientFactory()
+
+	syntheticHandler, err := getSyntheticEventHandlerWithRetries(workCtx, state.Event, clientFactory)
+	if err == nil {
+		return syntheticHandler.ResumeBatch(workCtx, replyCtx, state)
+	}
+
+	err = fmt.Errorf("cannot resume synthetic batch: %w", err)
+	if !isPermanentResumeError(err) {
+		log.WithError(err).WithField("keptnContext", state.KeptnContext).Error("Keeping state of synthetic batch, resuming it is attempted again after the next restart")
+		return err
+	}
+
+	log.Error(err.Error())
+
+	cleanUpSyntheticBatch(replyCtx, state, clientFactory)
+
+	deleteErr := batchStates.Delete(replyCtx, state)
+	if deleteErr != nil {
+		log.WithError(deleteErr).Error("Could not delete state of synthetic batch")
+	}
+
+	return NewErrorHandler(err, state.Event, clientFactory.CreateUniformClient()).HandleEvent(workCtx, replyCtx)
+}
+
+// getSyntheticEventHandlerWithRetries creates the synthetic test handler for the event, retrying with an increasing interval as long as the error is not permanent and workCtx is not done.
+func getSyntheticEventHandlerWithRetries(workCtx context.Context, event cloudevents.Event, clientFactory keptn.ClientFactoryInterface) (*synthetic.SyntheticTriggerEventHandler, error) {
+	interval := resumeRetryInterval
+	for attempt := 1; ; attempt++ {
+		syntheticHandler, err := getSyntheticEventHandler(workCtx, event, clientFactory)
+		if err == nil || isPermanentResumeError(err) || attempt == resumeMaxAttempts {
+			return syntheticHandler, err
+		}
+
+		log.WithError(err).WithField("attempt", attempt).Warnf("Could not create handler to resume synthetic batch, retrying in %s", interval)
+
+		timer := time.NewTimer(interval)
+		select {
+		case <-workCtx.Done():
+			timer.Stop()
+			return nil, err
+		case <-timer.C:
+		}
+
+		interval *= 2
+		if interval > resumeMaxRetryInterval {
+			interval = resumeMaxRetryInterval
+		}
+	}
+}
+
+func getSyntheticEventHandler(workCtx context.Context, event cloudevents.Event, clientFactory keptn.ClientFactoryInterface) (*synthetic.SyntheticTriggerEventHandler, error) {
+	eventHandler, err := getEventHandler(workCtx, event, clientFactory)
+	if err != nil {
+		return nil, err
+	}
+
+	syntheticHandler, ok := eventHandler.(*synthetic.SyntheticTriggerEventHandler)
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", errNotSyntheticEvent, event.Type())
+	}
+
+	return syntheticHandler, nil
+}
+
+// isPermanentResumeError checks whether the batch can never be resumed, i.e. if the event cannot be parsed or is not a synthetic test, or if the dynatrace.conf.yaml does not exist.
+func isPermanentResumeError(err error) bool {
+	var parseErr *adapter.CloudEventPayloadParseError
+	var rnfErr *keptn.ResourceNotFoundError
+	var emptyErr *keptn.ResourceEmptyError
+	return errors.Is(err, errNotSyntheticEvent) || errors.As(err, &parseErr) || errors.As(err, &rnfErr) || errors.As(err, &emptyErr)
+}
+
+// cleanUpSyntheticBatch disables the monitors enabled for the test and deletes the monitor created for it, like the handler does once a test is finished.
+func cleanUpSyntheticBatch(replyCtx context.Context, state synthetic.BatchState, clientFactory keptn.ClientFactoryInterface) {
+	if len(state.EnabledMonitorIds) == 0 && state.EphemeralMonitor == nil {
+		return
+	}
+
+	dtClient, err := getCleanUpDynatraceClient(replyCtx, state.Event, clientFactory)
+	if err != nil {
+		log.WithError(err).WithFields(log.Fields{"enabledMonitorIds": state.EnabledMonitorIds, "ephemeralMonitor": state.EphemeralMonitor}).Error("Could not clean up synthetic monitors of the batch")
+		return
+	}
+
+	synthetic.CleanUpBatchState(replyCtx, dtClient, state)
+}
+
+// getCleanUpDynatraceClient creates a Dynatrace client using the credentials of the dynatrace.conf.yaml or, if it cannot be retrieved, the default credentials.
+func getCleanUpDynatraceClient(ctx context.Context, event cloudevents.Event, clientFactory keptn.ClientFactoryInterface) (dynatrace.ClientInterface, error) {
+	dtCreds := config.NewDynatraceConfigWithDefaults().DtCreds
+
+	keptnEvent, err := synthetic.NewSyntheticTriggerAdapterFromEvent(event)
+	if err == nil {
+		dynatraceConfig, err := config.NewDynatraceConfigGetter(keptn.NewConfigClient(clientFactory.CreateResourceClient())).GetDynatraceConfig(keptnEvent)
+		if err == nil && dynatraceConfig.DtCreds != "" {
+			dtCreds = dynatraceConfig.DtCreds
+		}
+	}
+
+	dynatraceCredentialsProvider, err := credentials.NewDefaultDynatraceK8sSecretReader()
+	if err != nil {
+		return nil, fmt.Errorf("could not create Kubernetes secret reader: %w", err)
+	}
+
+	dynatraceCredentials, err := dynatraceCredentialsProvider.GetDynatraceCredentials(ctx, dtCreds)
+	if err != nil {
+		return nil, fmt.Errorf("could not get Dynatrace credentials: %w", err)
+	}
+
+	return dynatrace.NewClient(dynatraceCredentials), nil
+}
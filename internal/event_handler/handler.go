@@ -2,6 +2,7 @@ package event_handler
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -52,6 +53,11 @@ func getEventHandler(ctx context.Context, event cloudevents.Event, clientFactory
 		return NoOpHandler{}, nil
 	}
 
+	// aborting running synthetic tests requires neither configuration nor credentials
+	if abortedAdapter, ok := keptnEvent.(*synthetic.TestAbortedAdapter); ok {
+		return synthetic.NewTestAbortedEventHandler(abortedAdapter, synthetic.DefaultRunningTests), nil
+	}
+
 	if keptnEvent.GetProject() == "" {
 		return nil, errors.New("event has no project")
 	}
@@ -106,7 +112,14 @@ func getEventHandler(ctx context.Context, event cloudevents.Event, clientFactory
 	// case *action.ReleaseTriggeredAdapter:
 	// 	return action.NewReleaseTriggeredEventHandler(keptnEvent.(*action.ReleaseTriggeredAdapter), dtClient, clientFactory.CreateEventClient(), dynatraceConfig.AttachRules), nil
 	case *synthetic.SyntheticTriggerAdapter:
-		return synthetic.NewSyntheticTriggerEventHandler(keptnEvent.(*synthetic.SyntheticTriggerAdapter), dtClient, sClient, kClient, clientFactory.CreateEventClient(), dynatraceConfig.AttachRules), nil
+		batchStates, err := synthetic.NewDefaultBatchStateStore()
+		if err != nil {
+			return nil, fmt.Errorf("could not create synthetic batch state store: %w", err)
+		}
+
+		return synthetic.NewSyntheticTriggerEventHandler(keptnEvent.(*synthetic.SyntheticTriggerAdapter), dtClient, sClient, kClient, clientFactory.CreateEventClient(), keptn.NewConfigClient(clientFactory.CreateResourceClient()), batchStates, synthetic.DefaultRunningTests, dynatraceConfig.AttachRules, dynatraceConfig.Synthetic), nil
+	case *synthetic.ExternalTestFinishedAdapter:
+		return synthetic.NewExternalTestFinishedEventHandler(keptnEvent.(*synthetic.ExternalTestFinishedAdapter), dtClient, keptn.NewConfigClient(clientFactory.CreateResourceClient())), nil
 	default:
 		return NewErrorHandler(fmt.Errorf("this should not have happened, we are missing an implementation for: %T", aType), event, clientFactory.CreateUniformClient()), nil
 	}
@@ -116,6 +129,10 @@ func getEventAdapter(e cloudevents.Event) (adapter.EventContentAdapter, error) {
 	switch e.Type() {
 	case keptnv2.GetTriggeredEventType("test"):
 		return synthetic.NewSyntheticTriggerAdapterFromEvent(e)
+	case synthetic.SequenceAbortedEventType, synthetic.TestAbortedEventType:
+		return synthetic.NewTestAbortedAdapterFromEvent(e)
+	case keptnv2.GetFinishedEventType(keptnv2.TestTaskName):
+		return getExternalTestFinishedAdapter(e)
 	// case keptnevents.ConfigureMonitoringEventType:
 	// 	return monitoring.NewConfigureMonitoringAdapterFromEvent(e)
 	// case keptnevents.ProblemEventType:
@@ -146,3 +163,42 @@ func getEventAdapter(e cloudevents.Event) (adapter.EventContentAdapter, error) {
 		return nil, nil
 	}
 }
+
+// getExternalTestFinishedAdapter gets an adapter for test finished events declaring third-party test results.
+// Other test finished events, including the ones sent by this service for synthetic tests, are ignored.
+func getExternalTestFinishedAdapter(e cloudevents.Event) (adapter.EventContentAdapter, error) {
+	if e.Source() == adapter.GetEventSource() {
+		return nil, nil
+	}
+
+	testFinishedAdapter, err := synthetic.NewExternalTestFinishedAdapterFromEvent(e)
+	if err != nil {
+		if !declaresSyntheticResults(e) {
+			log.WithError(err).WithField("EventType", e.Type()).Debug("Ignoring test finished event which cannot be parsed and does not declare synthetic results")
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	if testFinishedAdapter.GetSyntheticResults() == nil {
+		log.WithField("EventType", e.Type()).Debug("Ignoring test finished event without synthetic results")
+		return nil, nil
+	}
+
+	return testFinishedAdapter, nil
+}
+
+// declaresSyntheticResults checks whether the data of the event contains synthetic results, regardless of whether the rest of the data can be parsed.
+func declaresSyntheticResults(e cloudevents.Event) bool {
+	data := struct {
+		SyntheticResults json.RawMessage `json:"syntheticResults"`
+	}{}
+
+	err := adapter.NewCloudEventAdapter(e).PayloadAs(&data)
+	if err != nil {
+		return false
+	}
+
+	return len(data.SyntheticResults) > 0 && string(data.SyntheticResults) != "null"
+}
@@ -37,9 +37,53 @@ type DynatraceConfigReaderInterface interface {
 	GetDynatraceConfig(project string, stage string, service string) (string, error)
 }
 
+// SyntheticMonitorsReaderInterface provides functionality for getting the declared synthetic monitors.
+type SyntheticMonitorsReaderInterface interface {
+	// GetSyntheticMonitors gets the synthetic monitors declaration for the specified project, stage and service, checking first on the service, then stage and then project level.
+	GetSyntheticMonitors(project string, stage string, service string) (string, error)
+}
+
+// SyntheticChecksReaderInterface provides functionality for getting the HTTP checks executed by the service itself.
+type SyntheticChecksReaderInterface interface {
+	// GetSyntheticChecks gets the synthetic checks declaration for the specified project, stage and service, checking first on the service, then stage and then project level.
+	GetSyntheticChecks(project string, stage string, service string) (string, error)
+}
+
+// SyntheticReportsWriterInterface provides functionality for uploading the reports of synthetic tests.
+type SyntheticReportsWriterInterface interface {
+	// UploadSyntheticReport uploads the report to the specified resource for the specified project, stage and service.
+	UploadSyntheticReport(project string, stage string, service string, resourceURI string, report []byte) error
+}
+
+// SyntheticHistoryClientInterface provides functionality for getting and uploading the result history of synthetic monitors.
+type SyntheticHistoryClientInterface interface {
+	// GetSyntheticHistory gets the result history of the synthetic monitors for the specified project, stage and service.
+	GetSyntheticHistory(project string, stage string, service string) (string, error)
+
+	// UploadSyntheticHistory uploads the result history of the synthetic monitors for the specified project, stage and service.
+	UploadSyntheticHistory(project string, stage string, service string, history []byte) error
+}
+
+// SyntheticResourcesClientInterface provides functionality for getting the declared synthetic monitors and checks, uploading the reports of synthetic tests and keeping the result history of synthetic monitors.
+type SyntheticResourcesClientInterface interface {
+	SyntheticMonitorsReaderInterface
+	SyntheticChecksReaderInterface
+	SyntheticReportsWriterInterface
+	SyntheticHistoryClientInterface
+}
+
+// SyntheticResultsReaderInterface provides functionality for getting the results of tests executed outside of Dynatrace Synthetic.
+type SyntheticResultsReaderInterface interface {
+	// GetSyntheticResults gets the test results stored in the specified resource for the specified project, stage and service, checking first on the service, then stage and then project level.
+	GetSyntheticResults(project string, stage string, service string, resourceURI string) (string, error)
+}
+
 const sloFilename = "slo.yaml"
 const sliFilename = "dynatrace/sli.yaml"
 const configFilename = "dynatrace/dynatrace.conf.yaml"
+const syntheticMonitorsFilename = "dynatrace/synthetic.yaml"
+const syntheticChecksFilename = "dynatrace/synthetic-checks.yaml"
+const syntheticHistoryFilename = "synthetic/history.json"
 
 // ConfigClient is the default implementation for ResourceClientInterface using a ConfigResourceClientInterface.
 type ConfigClient struct {
@@ -93,3 +137,33 @@ func (rc *ConfigClient) UploadSLIs(project string, stage string, service string,
 func (rc *ConfigClient) GetDynatraceConfig(project string, stage string, service string) (string, error) {
 	return rc.client.GetResource(project, stage, service, configFilename)
 }
+
+// GetSyntheticMonitors gets the synthetic monitors declaration for the specified project, stage and service, checking first on the service, then stage and then project level.
+func (rc *ConfigClient) GetSyntheticMonitors(project string, stage string, service string) (string, error) {
+	return rc.client.GetResource(project, stage, service, syntheticMonitorsFilename)
+}
+
+// GetSyntheticChecks gets the synthetic checks declaration for the specified project, stage and service, checking first on the service, then stage and then project level.
+func (rc *ConfigClient) GetSyntheticChecks(project string, stage string, service string) (string, error) {
+	return rc.client.GetResource(project, stage, service, syntheticChecksFilename)
+}
+
+// GetSyntheticResults gets the test results stored in the specified resource for the specified project, stage and service, checking first on the service, then stage and then project level.
+func (rc *ConfigClient) GetSyntheticResults(project string, stage string, service string, resourceURI string) (string, error) {
+	return rc.client.GetResource(project, stage, service, resourceURI)
+}
+
+// UploadSyntheticReport uploads the report to the specified resource for the specified project, stage and service.
+func (rc *ConfigClient) UploadSyntheticReport(project string, stage string, service string, resourceURI string, report []byte) error {
+	return rc.client.UploadResource(report, resourceURI, project, stage, service)
+}
+
+// GetSyntheticHistory gets the result history of the synthetic monitors for the specified project, stage and service, checking first on the service, then stage and then project level.
+func (rc *ConfigClient) GetSyntheticHistory(project string, stage string, service string) (string, error) {
+	return rc.client.GetResource(project, stage, service, syntheticHistoryFilename)
+}
+
+// UploadSyntheticHistory uploads the result history of the synthetic monitors for the specified project, stage and service.
+func (rc *ConfigClient) UploadSyntheticHistory(project string, stage string, service string, history []byte) error {
+	return rc.client.UploadResource(history, syntheticHistoryFilename, project, stage, service)
+}
@@ -3,10 +3,12 @@ package keptn
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
 	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn/go-utils/pkg/api/models"
 	api "github.com/keptn/go-utils/pkg/api/utils"
 	keptncommon "github.com/keptn/go-utils/pkg/lib"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
@@ -23,8 +25,16 @@ type EventClientInterface interface {
 
 	// GetImageAndTag extracts the image and tag associated with a deployment triggered as part of the sequence.
 	GetImageAndTag(keptnEvent adapter.EventContentAdapter) common.ImageAndTag
+
+	// GetPreviousTestFinishedEventData decodes the data of the latest succeeded test finished event sent by the specified source for the same project, stage and service in another Keptn context.
+	// It returns false if there is no such event or an error if the events could not be retrieved or decoded.
+	GetPreviousTestFinishedEventData(event adapter.EventContentAdapter, source string, eventData interface{}) (bool, error)
 }
 
+// previousTestFinishedEventsPageSizes are the sizes of the first page of test finished events retrieved one after another when searching for the previous test.
+// Usually, the previous test is found within the first few events, while the last size limits the number of events searched.
+var previousTestFinishedEventsPageSizes = []int{20, 100, 500}
+
 // EventClient implements offers EventClientInterface using api.EventsV1Interface.
 type EventClient struct {
 	client api.EventsV1Interface
@@ -137,6 +147,67 @@ func (c *EventClient) GetImageAndTag(event adapter.EventContentAdapter) common.I
 	return common.NewNotAvailableImageAndTag()
 }
 
+// GetPreviousTestFinishedEventData decodes the data of the latest succeeded test finished event sent by the specified source for the same project, stage and service in another Keptn context.
+// It returns false if there is no such event or an error if the events could not be retrieved or decoded.
+// As the events cannot be filtered by source, the search is repeated with a larger page until the event is found, all events were searched or the largest page was searched.
+func (c *EventClient) GetPreviousTestFinishedEventData(event adapter.EventContentAdapter, source string, eventData interface{}) (bool, error) {
+	for _, pageSize := range previousTestFinishedEventsPageSizes {
+		events, mErr := c.client.GetEvents(
+			&api.EventFilter{
+				Project:       event.GetProject(),
+				Stage:         event.GetStage(),
+				Service:       event.GetService(),
+				EventType:     keptnv2.GetFinishedEventType(keptnv2.TestTaskName),
+				PageSize:      strconv.Itoa(pageSize),
+				NumberOfPages: 1,
+			})
+
+		if mErr != nil {
+			return false, fmt.Errorf("could not retrieve test.finished events: %s", mErr.GetMessage())
+		}
+
+		found, err := decodePreviousTestFinishedEventData(events, event, source, eventData)
+		if err != nil || found {
+			return found, err
+		}
+
+		if len(events) < pageSize {
+			return false, nil
+		}
+	}
+
+	return false, nil
+}
+
+// decodePreviousTestFinishedEventData decodes the data of the first succeeded test finished event of the events sent by the source in another Keptn context than the event.
+func decodePreviousTestFinishedEventData(events []*models.KeptnContextExtendedCE, event adapter.EventContentAdapter, source string, eventData interface{}) (bool, error) {
+	// events are returned starting with the latest one
+	for _, e := range events {
+		if e.Shkeptncontext == event.GetShKeptnContext() || e.Source == nil || *e.Source != source {
+			continue
+		}
+
+		finishedData := &keptnv2.EventData{}
+		err := keptnv2.Decode(e.Data, finishedData)
+		if err != nil {
+			return false, fmt.Errorf("could not decode test.finished event: %w", err)
+		}
+
+		if finishedData.Status != keptnv2.StatusSucceeded {
+			continue
+		}
+
+		err = keptnv2.Decode(e.Data, eventData)
+		if err != nil {
+			return false, fmt.Errorf("could not decode test.finished event: %w", err)
+		}
+
+		return true, nil
+	}
+
+	return false, nil
+}
+
 // getImage returns the deployed image
 func getImage(imageAndTag string) string {
 	if imageAndTag == common.NotAvailable {
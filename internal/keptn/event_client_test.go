@@ -0,0 +1,128 @@
+package keptn
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+	"github.com/keptn/go-utils/pkg/api/models"
+	api "github.com/keptn/go-utils/pkg/api/utils"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestFinishedEvent(keptnContext string, source string, status keptnv2.StatusType, result string) *models.KeptnContextExtendedCE {
+	eventType := keptnv2.GetFinishedEventType(keptnv2.TestTaskName)
+	return &models.KeptnContextExtendedCE{
+		Shkeptncontext: keptnContext,
+		Source:         &source,
+		Type:           &eventType,
+		Data: map[string]interface{}{
+			"project": "easytravel",
+			"stage":   "staging",
+			"service": "frontend",
+			"status":  status,
+			"result":  result,
+		},
+	}
+}
+
+// createTestFinishedEventsServer creates a server returning the first page of the events with the requested size and records the requested page sizes.
+func createTestFinishedEventsServer(t *testing.T, events []*models.KeptnContextExtendedCE, requestedPageSizes *[]int) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, keptnv2.GetFinishedEventType(keptnv2.TestTaskName), r.URL.Query().Get("type"))
+		assert.Equal(t, "frontend", r.URL.Query().Get("service"))
+		assert.Empty(t, r.URL.Query().Get("nextPageKey"), "only the first page should be retrieved")
+
+		pageSize, err := strconv.Atoi(r.URL.Query().Get("pageSize"))
+		assert.NoError(t, err)
+		*requestedPageSizes = append(*requestedPageSizes, pageSize)
+
+		page := models.Events{Events: events}
+		if pageSize < len(events) {
+			page = models.Events{Events: events[:pageSize], NextPageKey: strconv.Itoa(pageSize)}
+		}
+
+		body, err := json.Marshal(page)
+		assert.NoError(t, err)
+		w.Write(body)
+	}))
+}
+
+// createOtherTestFinishedEvents creates test finished events of other sources.
+func createOtherTestFinishedEvents(count int) []*models.KeptnContextExtendedCE {
+	events := []*models.KeptnContextExtendedCE{}
+	for i := 0; i < count; i++ {
+		events = append(events, createTestFinishedEvent(fmt.Sprintf("other-%d", i), "jmeter-service", keptnv2.StatusSucceeded, "fail"))
+	}
+
+	return events
+}
+
+// TestEventClient_GetPreviousTestFinishedEventData tests that the previous test is searched for within larger pages of test finished events until it is found.
+func TestEventClient_GetPreviousTestFinishedEventData(t *testing.T) {
+	tests := []struct {
+		name                   string
+		events                 []*models.KeptnContextExtendedCE
+		source                 string
+		wantFound              bool
+		wantResult             keptnv2.ResultType
+		wantRequestedPageSizes []int
+	}{
+		{
+			name: "found within the first page",
+			events: []*models.KeptnContextExtendedCE{
+				createTestFinishedEvent("context-2", "dynatrace-service", keptnv2.StatusSucceeded, "pass"),
+				createTestFinishedEvent("context-1", "jmeter-service", keptnv2.StatusSucceeded, "fail"),
+				createTestFinishedEvent("context-1", "dynatrace-service", keptnv2.StatusErrored, "fail"),
+				createTestFinishedEvent("context-0", "dynatrace-service", keptnv2.StatusSucceeded, "warning"),
+			},
+			source:                 "dynatrace-service",
+			wantFound:              true,
+			wantResult:             keptnv2.ResultWarning,
+			wantRequestedPageSizes: []int{20},
+		},
+		{
+			name:                   "found within a larger page",
+			events:                 append(createOtherTestFinishedEvents(30), createTestFinishedEvent("context-0", "dynatrace-service", keptnv2.StatusSucceeded, "pass")),
+			source:                 "dynatrace-service",
+			wantFound:              true,
+			wantResult:             keptnv2.ResultPass,
+			wantRequestedPageSizes: []int{20, 100},
+		},
+		{
+			name:                   "not found within all events",
+			events:                 createOtherTestFinishedEvents(30),
+			source:                 "dynatrace-service",
+			wantRequestedPageSizes: []int{20, 100},
+		},
+		{
+			name:                   "not found within the largest page",
+			events:                 append(createOtherTestFinishedEvents(600), createTestFinishedEvent("context-0", "dynatrace-service", keptnv2.StatusSucceeded, "pass")),
+			source:                 "dynatrace-service",
+			wantRequestedPageSizes: []int{20, 100, 500},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requestedPageSizes := []int{}
+			server := createTestFinishedEventsServer(t, tt.events, &requestedPageSizes)
+			defer server.Close()
+
+			event := &test.EventData{Context: "context-2", Project: "easytravel", Stage: "staging", Service: "frontend"}
+			client := NewEventClient(api.NewEventHandler(server.URL))
+
+			eventData := keptnv2.EventData{}
+			found, err := client.GetPreviousTestFinishedEventData(event, tt.source, &eventData)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantFound, found)
+			assert.Equal(t, tt.wantResult, eventData.Result)
+			assert.Equal(t, tt.wantRequestedPageSizes, requestedPageSizes)
+		})
+	}
+}
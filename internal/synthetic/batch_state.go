@@ -0,0 +1,74 @@
+package synthetic
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/env"
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+)
+
+const fileBatchStateStoreType = "file"
+const configMapBatchStateStoreType = "configmap"
+
+// BatchState is the state of a running synthetic test which is persisted, so that waiting for its batch can be resumed after the service was restarted.
+type BatchState struct {
+	KeptnContext     string    `json:"keptnContext"`
+	TriggeredEventId string    `json:"triggeredEventId"`
+	StartTime        time.Time `json:"startTime"`
+
+	// Batch is the batch currently waited for, including its id and execution options
+	Batch connector.TriggeredBatch `json:"batch"`
+
+	// Attempts are the finished attempts preceding the current batch, i.e. the initial batch and re-triggered batches
+	Attempts []connector.ExecutionData `json:"attempts,omitempty"`
+
+	EphemeralMonitor  *EphemeralMonitor `json:"ephemeralMonitor,omitempty"`
+	EnabledMonitorIds []string          `json:"enabledMonitorIds,omitempty"`
+
+	// Event is the test triggered event the test was started for
+	Event cloudevents.Event `json:"event"`
+}
+
+// CleanUpBatchState disables the monitors enabled for the test of the state and deletes the monitor created for it, e.g. if the test cannot be resumed.
+func CleanUpBatchState(replyCtx context.Context, dtClient dynatrace.ClientInterface, state BatchState) {
+	cleanUpMonitors(replyCtx, dynatrace.NewSyntheticMonitorsClient(dtClient), state.EnabledMonitorIds, state.EphemeralMonitor)
+}
+
+// getBatchStateKey returns the key a batch state is stored with. As a Keptn context may contain several test triggered events, the triggered event id is included.
+func getBatchStateKey(state BatchState) string {
+	return fmt.Sprintf("%s_%s", state.KeptnContext, state.TriggeredEventId)
+}
+
+// BatchStateStore persists the state of running synthetic tests.
+type BatchStateStore interface {
+	// Save creates or replaces the state of a synthetic test.
+	Save(ctx context.Context, state BatchState) error
+
+	// Delete deletes the state of a synthetic test. Deleting a state which does not exist is not an error.
+	Delete(ctx context.Context, state BatchState) error
+
+	// List lists the states of all synthetic tests which have not been finished.
+	List(ctx context.Context) ([]BatchState, error)
+}
+
+// NewDefaultBatchStateStore creates the BatchStateStore configured by the SYNTHETIC_BATCH_STATE_STORE environment variable or returns nil if the state is not persisted.
+func NewDefaultBatchStateStore() (BatchStateStore, error) {
+	switch storeType := env.GetSyntheticBatchStateStore(); storeType {
+	case "":
+		return nil, nil
+	case fileBatchStateStoreType:
+		return NewFileBatchStateStore(env.GetSyntheticBatchStateDirectory()), nil
+	case configMapBatchStateStoreType:
+		store, err := NewDefaultConfigMapBatchStateStore()
+		if err != nil {
+			return nil, err
+		}
+		return store, nil
+	default:
+		return nil, fmt.Errorf("unknown synthetic batch state store: %s", storeType)
+	}
+}
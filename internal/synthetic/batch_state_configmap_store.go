@@ -0,0 +1,124 @@
+package synthetic
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/env"
+	keptnkubeutils "github.com/keptn/kubernetes-utils/pkg"
+	log "github.com/sirupsen/logrus"
+	corev1 "k8s.io/api/core/v1"
+	k8serrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/util/retry"
+)
+
+// ConfigMapBatchStateStore persists the states of all synthetic tests to a single ConfigMap, using one key per test.
+type ConfigMapBatchStateStore struct {
+	k8sClient kubernetes.Interface
+	namespace string
+	name      string
+}
+
+// NewConfigMapBatchStateStore creates a new ConfigMapBatchStateStore using the specified kubernetes.Interface and ConfigMap, which is created if it does not exist.
+func NewConfigMapBatchStateStore(k8sClient kubernetes.Interface, namespace string, name string) *ConfigMapBatchStateStore {
+	return &ConfigMapBatchStateStore{
+		k8sClient: k8sClient,
+		namespace: namespace,
+		name:      name,
+	}
+}
+
+// NewDefaultConfigMapBatchStateStore creates a new ConfigMapBatchStateStore using the default K8s client and the configured ConfigMap in the namespace of the pod.
+func NewDefaultConfigMapBatchStateStore() (*ConfigMapBatchStateStore, error) {
+	useInClusterConfig := env.GetKubernetesServiceHost() != ""
+	k8sClient, err := keptnkubeutils.GetClientset(useInClusterConfig)
+	if err != nil {
+		return nil, fmt.Errorf("could not initialize ConfigMapBatchStateStore: %s", err.Error())
+	}
+	return NewConfigMapBatchStateStore(k8sClient, env.GetPodNamespace(), env.GetSyntheticBatchStateConfigMap()), nil
+}
+
+// Save sets the key of the state in the ConfigMap, creating the ConfigMap if required.
+func (s *ConfigMapBatchStateStore) Save(ctx context.Context, state BatchState) error {
+	content, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("could not serialize batch state: %w", err)
+	}
+
+	return s.update(ctx, func(data map[string]string) {
+		data[getBatchStateKey(state)] = string(content)
+	})
+}
+
+// Delete removes the key of the state from the ConfigMap.
+func (s *ConfigMapBatchStateStore) Delete(ctx context.Context, state BatchState) error {
+	return s.update(ctx, func(data map[string]string) {
+		delete(data, getBatchStateKey(state))
+	})
+}
+
+// List reads the states from all keys of the ConfigMap. A ConfigMap which does not exist contains no states.
+// Keys which cannot be deserialized are logged and skipped, so that they do not prevent resuming the other tests.
+func (s *ConfigMapBatchStateStore) List(ctx context.Context) ([]BatchState, error) {
+	configMap, err := s.k8sClient.CoreV1().ConfigMaps(s.namespace).Get(ctx, s.name, metav1.GetOptions{})
+	if err != nil {
+		if k8serrors.IsNotFound(err) {
+			return []BatchState{}, nil
+		}
+
+		return nil, fmt.Errorf("could not read batch state ConfigMap %s: %w", s.name, err)
+	}
+
+	states := []BatchState{}
+	for key, content := range configMap.Data {
+		state := BatchState{}
+		err = json.Unmarshal([]byte(content), &state)
+		if err != nil {
+			log.WithError(err).WithField("key", key).Error("Could not deserialize batch state, skipping it")
+			continue
+		}
+
+		states = append(states, state)
+	}
+
+	return states, nil
+}
+
+// update applies the modification to the data of the ConfigMap and retries on conflicts, as several tests may modify the ConfigMap concurrently.
+func (s *ConfigMapBatchStateStore) update(ctx context.Context, modify func(data map[string]string)) error {
+	configMaps := s.k8sClient.CoreV1().ConfigMaps(s.namespace)
+
+	err := retry.RetryOnConflict(retry.DefaultRetry, func() error {
+		configMap, err := configMaps.Get(ctx, s.name, metav1.GetOptions{})
+		if k8serrors.IsNotFound(err) {
+			data := map[string]string{}
+			modify(data)
+			_, err = configMaps.Create(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: s.name, Namespace: s.namespace}, Data: data}, metav1.CreateOptions{})
+
+			// a concurrently created ConfigMap is updated in the next attempt
+			if k8serrors.IsAlreadyExists(err) {
+				return k8serrors.NewConflict(corev1.Resource("configmaps"), s.name, err)
+			}
+			return err
+		}
+		if err != nil {
+			return err
+		}
+
+		if configMap.Data == nil {
+			configMap.Data = map[string]string{}
+		}
+		modify(configMap.Data)
+
+		_, err = configMaps.Update(ctx, configMap, metav1.UpdateOptions{})
+		return err
+	})
+	if err != nil {
+		return fmt.Errorf("could not update batch state ConfigMap %s: %w", s.name, err)
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package synthetic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	log "github.com/sirupsen/logrus"
+)
+
+const batchStateFileExtension = ".json"
+
+// FileBatchStateStore persists the state of each synthetic test to a JSON file in a directory, e.g. on a persistent volume.
+type FileBatchStateStore struct {
+	directory string
+}
+
+// NewFileBatchStateStore creates a new FileBatchStateStore using the specified directory, which is created if it does not exist.
+func NewFileBatchStateStore(directory string) *FileBatchStateStore {
+	return &FileBatchStateStore{
+		directory: directory,
+	}
+}
+
+func (s *FileBatchStateStore) getFilename(state BatchState) string {
+	return filepath.Join(s.directory, getBatchStateKey(state)+batchStateFileExtension)
+}
+
+// Save writes the state to a temporary file first, so that an interrupted write does not leave a corrupt state behind.
+func (s *FileBatchStateStore) Save(ctx context.Context, state BatchState) error {
+	content, err := json.Marshal(state)
+	if err != nil {
+		return fmt.Errorf("could not serialize batch state: %w", err)
+	}
+
+	err = os.MkdirAll(s.directory, 0700)
+	if err != nil {
+		return fmt.Errorf("could not create batch state directory: %w", err)
+	}
+
+	filename := s.getFilename(state)
+	err = os.WriteFile(filename+".tmp", content, 0600)
+	if err != nil {
+		return fmt.Errorf("could not write batch state: %w", err)
+	}
+
+	err = os.Rename(filename+".tmp", filename)
+	if err != nil {
+		return fmt.Errorf("could not write batch state: %w", err)
+	}
+
+	return nil
+}
+
+// Delete deletes the file of the state.
+func (s *FileBatchStateStore) Delete(ctx context.Context, state BatchState) error {
+	err := os.Remove(s.getFilename(state))
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("could not delete batch state: %w", err)
+	}
+
+	return nil
+}
+
+// List reads the states from all files in the directory. A directory which does not exist contains no states.
+// Files which cannot be read or deserialized are logged and skipped, so that they do not prevent resuming the other tests.
+func (s *FileBatchStateStore) List(ctx context.Context) ([]BatchState, error) {
+	entries, err := os.ReadDir(s.directory)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return []BatchState{}, nil
+		}
+
+		return nil, fmt.Errorf("could not read batch state directory: %w", err)
+	}
+
+	states := []BatchState{}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), batchStateFileExtension) {
+			continue
+		}
+
+		content, err := os.ReadFile(filepath.Join(s.directory, entry.Name()))
+		if err != nil {
+			log.WithError(err).WithField("file", entry.Name()).Error("Could not read batch state, skipping it")
+			continue
+		}
+
+		state := BatchState{}
+		err = json.Unmarshal(content, &state)
+		if err != nil {
+			log.WithError(err).WithField("file", entry.Name()).Error("Could not deserialize batch state, skipping it")
+			continue
+		}
+
+		states = append(states, state)
+	}
+
+	return states, nil
+}
@@ -0,0 +1,135 @@
+package synthetic
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+	"github.com/stretchr/testify/assert"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func createTestBatchState(t *testing.T, keptnContext string, batchId string) BatchState {
+	event := cloudevents.NewEvent()
+	event.SetID("triggered-" + batchId)
+	event.SetType("sh.keptn.event.test.triggered")
+	event.SetSource("shipyard-controller")
+	event.SetExtension("shkeptncontext", keptnContext)
+	err := event.SetData(cloudevents.ApplicationJSON, map[string]interface{}{
+		"project":   "easytravel",
+		"stage":     "staging",
+		"service":   "frontend",
+		"monitorId": "HTTP_CHECK-1",
+		"waitFor":   "execution",
+	})
+	assert.NoError(t, err)
+
+	return BatchState{
+		KeptnContext:     keptnContext,
+		TriggeredEventId: event.ID(),
+		StartTime:        time.Date(2022, 4, 15, 10, 0, 0, 0, time.UTC),
+		Batch: connector.TriggeredBatch{
+			ExecutionData: connector.ExecutionData{
+				BatchId:      batchId,
+				MonitorIds:   []string{"HTTP_CHECK-1"},
+				ExecutionIds: []string{"1"},
+			},
+			LocationIds: []string{"GEOLOCATION-1"},
+			Options:     connector.ExecutionOptions{ProcessingMode: connector.ProcessingModeStandard},
+			TriggerTime: time.Date(2022, 4, 15, 10, 0, 0, 0, time.UTC),
+		},
+		EnabledMonitorIds: []string{"HTTP_CHECK-1"},
+		Event:             event,
+	}
+}
+
+// testBatchStateStore tests that states are saved, replaced, listed and deleted and that the persisted event can be adapted again
+func testBatchStateStore(t *testing.T, store BatchStateStore) {
+	states, err := store.List(context.TODO())
+	assert.NoError(t, err)
+	assert.Empty(t, states)
+
+	first := createTestBatchState(t, "01234567-0123-0123-0123-012345678901", "1")
+	second := createTestBatchState(t, "01234567-0123-0123-0123-012345678902", "2")
+	assert.NoError(t, store.Save(context.TODO(), first))
+	assert.NoError(t, store.Save(context.TODO(), second))
+
+	first.Attempts = []connector.ExecutionData{first.Batch.ExecutionData}
+	first.Batch.ExecutionData.BatchId = "3"
+	assert.NoError(t, store.Save(context.TODO(), first))
+
+	states, err = store.List(context.TODO())
+	assert.NoError(t, err)
+	if assert.Len(t, states, 2) {
+		listedFirst := states[0]
+		if listedFirst.KeptnContext != first.KeptnContext {
+			listedFirst = states[1]
+		}
+
+		assert.Equal(t, "3", listedFirst.Batch.ExecutionData.BatchId)
+		assert.Equal(t, first.Attempts, listedFirst.Attempts)
+		assert.Equal(t, first.StartTime, listedFirst.StartTime)
+		assert.Equal(t, first.Batch.Options, listedFirst.Batch.Options)
+		assert.Equal(t, first.EnabledMonitorIds, listedFirst.EnabledMonitorIds)
+
+		adapter, err := NewSyntheticTriggerAdapterFromEvent(listedFirst.Event)
+		assert.NoError(t, err)
+		assert.Equal(t, first.KeptnContext, adapter.GetShKeptnContext())
+		assert.Equal(t, first.TriggeredEventId, adapter.GetEventID())
+		assert.Equal(t, "frontend", adapter.GetService())
+		assert.Equal(t, []string{"HTTP_CHECK-1"}, adapter.GetSyntheticMonitorIds())
+		assert.True(t, adapter.IsWaitForExecutionRequested())
+	}
+
+	assert.NoError(t, store.Delete(context.TODO(), first))
+	assert.NoError(t, store.Delete(context.TODO(), first))
+
+	states, err = store.List(context.TODO())
+	assert.NoError(t, err)
+	if assert.Len(t, states, 1) {
+		assert.Equal(t, second.KeptnContext, states[0].KeptnContext)
+	}
+}
+
+func TestFileBatchStateStore(t *testing.T) {
+	testBatchStateStore(t, NewFileBatchStateStore(t.TempDir()+"/batches"))
+}
+
+// TestFileBatchStateStore_ListSkipsCorruptStates tests that a file which cannot be deserialized does not prevent listing the other states.
+func TestFileBatchStateStore_ListSkipsCorruptStates(t *testing.T) {
+	directory := t.TempDir()
+	store := NewFileBatchStateStore(directory)
+	state := createTestBatchState(t, "01234567-0123-0123-0123-012345678901", "1")
+	assert.NoError(t, store.Save(context.TODO(), state))
+	assert.NoError(t, os.WriteFile(filepath.Join(directory, "corrupt"+batchStateFileExtension), []byte("{"), 0600))
+
+	states, err := store.List(context.TODO())
+	assert.NoError(t, err)
+	if assert.Len(t, states, 1) {
+		assert.Equal(t, state.KeptnContext, states[0].KeptnContext)
+	}
+}
+
+func TestConfigMapBatchStateStore(t *testing.T) {
+	testBatchStateStore(t, NewConfigMapBatchStateStore(fake.NewSimpleClientset(), "keptn", "dynatrace-synthetic-service-batches"))
+}
+
+// TestConfigMapBatchStateStore_ListSkipsCorruptStates tests that a key which cannot be deserialized does not prevent listing the other states.
+func TestConfigMapBatchStateStore_ListSkipsCorruptStates(t *testing.T) {
+	store := NewConfigMapBatchStateStore(fake.NewSimpleClientset(), "keptn", "dynatrace-synthetic-service-batches")
+	state := createTestBatchState(t, "01234567-0123-0123-0123-012345678901", "1")
+	assert.NoError(t, store.Save(context.TODO(), state))
+	assert.NoError(t, store.update(context.TODO(), func(data map[string]string) {
+		data["corrupt"] = "{"
+	}))
+
+	states, err := store.List(context.TODO())
+	assert.NoError(t, err)
+	if assert.Len(t, states, 1) {
+		assert.Equal(t, state.KeptnContext, states[0].KeptnContext)
+	}
+}
@@ -0,0 +1,184 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	log "github.com/sirupsen/logrus"
+)
+
+// Batch is a triggered batch of synthetic executions.
+type Batch interface {
+	// GetExecutionData returns the monitors and executions of the batch as triggered.
+	GetExecutionData() ExecutionData
+
+	// GetState returns the state of the batch, which can be persisted and restored using SyntheticConnectorInterface.RestoreBatch.
+	GetState() TriggeredBatch
+
+	// Wait waits for the batch to return a SUCCESS or FAILED status and returns its status and success rate.
+	Wait(workCtx context.Context, policy PollingPolicy) (BatchResponseBody, float64, error)
+
+	// Report gets the full reports of all executions of the batch.
+	Report(workCtx context.Context) ([]ExecutionReport, error)
+
+	// Retrigger triggers the specified monitor and location pairs in a new batch, e.g. to re-run failed executions.
+	Retrigger(workCtx context.Context, monitorLocations []MonitorLocation) (Batch, error)
+}
+
+// TriggeredBatch is the state of a triggered batch which is required to wait for, re-trigger and report it, e.g. after the service was restarted
+type TriggeredBatch struct {
+	ExecutionData ExecutionData    `json:"executionData"`
+	LocationIds   []string         `json:"locationIds"`
+	Options       ExecutionOptions `json:"options"`
+	TriggerTime   time.Time        `json:"triggerTime"`
+}
+
+// SyntheticBatch is a batch triggered via the Dynatrace API.
+type SyntheticBatch struct {
+	dtClient dynatrace.ClientInterface
+	state    TriggeredBatch
+}
+
+// triggerBatch triggers a batch with the request body. The location ids, options and URL overrides are kept for re-triggering.
+func triggerBatch(workCtx context.Context, dtClient dynatrace.ClientInterface, jsonData []byte, locationIds []string, options ExecutionOptions, urlOverrides []UrlOverride) (*SyntheticBatch, error) {
+	triggerTime := time.Now().UTC()
+
+	resp, err := dtClient.Post(workCtx, syntheticBatchBasePath, jsonData)
+	if err != nil {
+		return nil, err
+	}
+
+	executionResponseBody := ExecutionResponseBody{}
+	err = json.Unmarshal(resp, &executionResponseBody)
+	if err != nil {
+		log.Error(err.Error())
+		return nil, err
+	}
+
+	return &SyntheticBatch{
+		dtClient: dtClient,
+		state: TriggeredBatch{
+			ExecutionData: ExecutionData{
+				BatchId:             parseBatchId(executionResponseBody),
+				MonitorIds:          parseMonitorIds(executionResponseBody),
+				ExecutionIds:        parseExecutionIds(executionResponseBody),
+				TriggeredExecutions: parseTriggeredExecutions(executionResponseBody),
+				FailedTriggers:      parseFailedTriggers(executionResponseBody),
+				UrlOverrides:        urlOverrides,
+			},
+			LocationIds: locationIds,
+			Options:     options,
+			TriggerTime: triggerTime,
+		},
+	}, nil
+}
+
+// GetExecutionData returns the monitors and executions of the batch as triggered.
+func (b *SyntheticBatch) GetExecutionData() ExecutionData {
+	return b.state.ExecutionData
+}
+
+// GetState returns the state of the batch.
+func (b *SyntheticBatch) GetState() TriggeredBatch {
+	return b.state
+}
+
+// Retrigger triggers the specified monitor and location pairs in a new batch, e.g. to re-run failed executions.
+// Pairs without a location id are triggered for the locations of this batch, all pairs use the options of this batch.
+func (b *SyntheticBatch) Retrigger(workCtx context.Context, monitorLocations []MonitorLocation) (Batch, error) {
+	if len(monitorLocations) == 0 {
+		return nil, errors.New("no monitors and locations to re-trigger")
+	}
+
+	urlOverrides := b.state.ExecutionData.UrlOverrides
+	jsonData, err := generateExecutionByMonitorLocationsEvent(monitorLocations, b.state.LocationIds, b.state.Options, urlOverrides)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Debug("Retrigger")
+	log.Debug(string(jsonData))
+
+	return triggerBatch(workCtx, b.dtClient, jsonData, b.state.LocationIds, b.state.Options, urlOverrides)
+}
+
+func (b *SyntheticBatch) getBatchExecutionData(workCtx context.Context) (BatchResponseBody, error) {
+	path := getSyntheticBatchPath(b.state.ExecutionData.BatchId)
+	resp, err := b.dtClient.Get(workCtx, path)
+	if err != nil {
+		return BatchResponseBody{}, err
+	}
+
+	log.Debug(string(resp))
+
+	batchResponseBody := BatchResponseBody{}
+	err = json.Unmarshal(resp, &batchResponseBody)
+	if err != nil {
+		log.Error(err.Error())
+		return BatchResponseBody{}, err
+	}
+
+	return batchResponseBody, nil
+}
+
+func (b *SyntheticBatch) getExecutionReport(workCtx context.Context, executionId string) (ExecutionReport, error) {
+	resp, err := b.dtClient.Get(workCtx, getSyntheticExecutionFullReportPath(executionId))
+	if err != nil {
+		return ExecutionReport{}, err
+	}
+
+	log.Debug(string(resp))
+
+	executionReport := ExecutionReport{}
+	err = json.Unmarshal(resp, &executionReport)
+	if err != nil {
+		log.Error(err.Error())
+		return ExecutionReport{}, err
+	}
+
+	return executionReport, nil
+}
+
+// Report gets the full reports of all executions of the batch.
+func (b *SyntheticBatch) Report(workCtx context.Context) ([]ExecutionReport, error) {
+	executionReports := make([]ExecutionReport, 0, len(b.state.ExecutionData.ExecutionIds))
+
+	for _, executionId := range b.state.ExecutionData.ExecutionIds {
+		executionReport, err := b.getExecutionReport(workCtx, executionId)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve report of synthetic execution %s: %w", executionId, err)
+		}
+
+		executionReports = append(executionReports, executionReport)
+	}
+
+	return executionReports, nil
+}
+
+// Wait waits for the batch to return a SUCCESS or FAILED status.
+//
+// Attention: Does not wait for data retrieval
+func (b *SyntheticBatch) Wait(workCtx context.Context, policy PollingPolicy) (BatchResponseBody, float64, error) {
+	batchResponseBody := BatchResponseBody{}
+
+	err := policy.poll(workCtx, func() (bool, error) {
+		var err error
+		batchResponseBody, err = b.getBatchExecutionData(workCtx)
+		if err != nil {
+			return false, err
+		}
+
+		// batchStatus = RUNNING || SUCCESS || FAILED
+		return batchResponseBody.BatchStatus == "SUCCESS" || batchResponseBody.BatchStatus == "FAILED", nil
+	})
+	if err != nil {
+		return BatchResponseBody{}, 0, err
+	}
+
+	successRate, _ := calculateSuccessRate(batchResponseBody)
+	return batchResponseBody, successRate, nil
+}
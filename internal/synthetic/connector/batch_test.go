@@ -0,0 +1,85 @@
+package connector
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSyntheticConnector_RestoreBatch tests that a batch can be restored from its persisted state and waited for.
+func TestSyntheticConnector_RestoreBatch(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/synthetic/executions/batch/1234", r.URL.Path)
+		_, _ = w.Write([]byte(`{"batchStatus":"SUCCESS","triggeredCount":2,"executedCount":2,"failedCount":0,"failedToExecuteCount":0}`))
+	})
+	sc, teardown := createSyntheticConnector(t, handler)
+	defer teardown()
+
+	takeScreenshotsOnSuccess := true
+	state := TriggeredBatch{
+		ExecutionData: ExecutionData{
+			BatchId:      "1234",
+			MonitorIds:   []string{"HTTP_CHECK-1"},
+			ExecutionIds: []string{"1", "2"},
+		},
+		LocationIds: []string{"GEOLOCATION-1", "GEOLOCATION-2"},
+		Options: ExecutionOptions{
+			ProcessingMode:           ProcessingModeStandard,
+			TakeScreenshotsOnSuccess: &takeScreenshotsOnSuccess,
+		},
+		TriggerTime: time.Date(2022, 4, 15, 10, 0, 0, 0, time.UTC),
+	}
+
+	persistedBatch, err := json.Marshal(state)
+	assert.NoError(t, err)
+
+	restoredState := TriggeredBatch{}
+	err = json.Unmarshal(persistedBatch, &restoredState)
+	assert.NoError(t, err)
+
+	restored := sc.RestoreBatch(restoredState)
+	assert.Equal(t, state, restored.GetState())
+
+	batchResponseBody, successRate, err := restored.Wait(context.TODO(), PollingPolicy{Timeout: time.Second, InitialInterval: time.Millisecond, BackoffFactor: 1, MaxInterval: time.Millisecond})
+	assert.NoError(t, err)
+	assert.Equal(t, "SUCCESS", batchResponseBody.BatchStatus)
+	assert.Equal(t, 100.0, successRate)
+}
+
+// TestSyntheticConnector_TriggerConcurrentBatches tests that the connector can be shared, i.e. each batch is waited for and re-triggered independently.
+func TestSyntheticConnector_TriggerConcurrentBatches(t *testing.T) {
+	handler := test.NewPayloadBasedURLHandler(t)
+	handler.AddExact("/api/v2/synthetic/executions/batch", []byte(`{"batchId":"1","triggeredCount":1,"triggered":[{"monitorId":"HTTP_CHECK-1","executions":[{"executionId":"11","locationId":"GEOLOCATION-1"}]}]}`))
+	handler.AddExact("/api/v2/synthetic/executions/batch/1", []byte(`{"batchStatus":"FAILED","triggeredCount":1,"executedCount":1,"failedCount":1,"failedExecutions":[{"executionId":"11","monitorId":"HTTP_CHECK-1","locationId":"GEOLOCATION-1"}]}`))
+	handler.AddExact("/api/v2/synthetic/executions/11/fullReport", []byte(`{"executionId":"11","monitorId":"HTTP_CHECK-1","locationId":"GEOLOCATION-1"}`))
+	sc, teardown := createSyntheticConnector(t, handler)
+	defer teardown()
+
+	first, err := sc.Trigger(context.TODO(), MonitorSelection{MonitorIds: []string{"HTTP_CHECK-1"}}, []string{"GEOLOCATION-1"}, ExecutionOptions{})
+	assert.NoError(t, err)
+
+	second := sc.RestoreBatch(TriggeredBatch{ExecutionData: ExecutionData{BatchId: "2", ExecutionIds: []string{}}})
+
+	assert.Equal(t, "1", first.GetExecutionData().BatchId)
+	assert.Equal(t, []string{"11"}, first.GetExecutionData().ExecutionIds)
+	assert.Equal(t, []string{"GEOLOCATION-1"}, first.GetState().LocationIds)
+	assert.Equal(t, "2", second.GetExecutionData().BatchId)
+
+	batchResponseBody, successRate, err := first.Wait(context.TODO(), PollingPolicy{Timeout: time.Second, InitialInterval: time.Millisecond, BackoffFactor: 1, MaxInterval: time.Millisecond})
+	assert.NoError(t, err)
+	assert.Equal(t, "FAILED", batchResponseBody.BatchStatus)
+	assert.Equal(t, 0.0, successRate)
+
+	reports, err := first.Report(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, []ExecutionReport{{ExecutionId: "11", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1"}}, reports)
+
+	reports, err = second.Report(context.TODO())
+	assert.NoError(t, err)
+	assert.Empty(t, reports)
+}
@@ -0,0 +1,64 @@
+package connector
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ProcessingModeStandard processes executions as usual, i.e. they may open problems
+const ProcessingModeStandard = "STANDARD"
+
+// ProcessingModeDisableProblemDetection processes executions without opening problems
+const ProcessingModeDisableProblemDetection = "DISABLE_PROBLEM_DETECTION"
+
+// ProcessingModeExecutionsDetailsOnly only stores the details of executions, i.e. no metrics are calculated and no problems are opened
+const ProcessingModeExecutionsDetailsOnly = "EXECUTIONS_DETAILS_ONLY"
+
+// ExecutionOptions are the options applied to the executions of a batch. Options which are not set use the defaults of Dynatrace.
+type ExecutionOptions struct {
+	ProcessingMode           string            `json:"processingMode,omitempty"`
+	FailOnPerformanceIssue   *bool             `json:"failOnPerformanceIssue,omitempty"`
+	FailOnSslWarning         *bool             `json:"failOnSslWarning,omitempty"`
+	StopOnProblem            *bool             `json:"stopOnProblem,omitempty"`
+	TakeScreenshotsOnSuccess *bool             `json:"takeScreenshotsOnSuccess,omitempty"`
+	CustomizedScript         *CustomizedScript `json:"customizedScript,omitempty"`
+
+	// BaseUrl is the URL the requests of HTTP monitors and the navigate events of browser monitors are rewritten to
+	BaseUrl string `json:"baseUrl,omitempty"`
+}
+
+// Validate validates the options and normalizes the processing mode to upper case.
+func (o *ExecutionOptions) Validate() error {
+	if o.BaseUrl != "" {
+		_, err := parseBaseUrl(o.BaseUrl)
+		if err != nil {
+			return err
+		}
+	}
+
+	if o.ProcessingMode == "" {
+		return nil
+	}
+
+	processingMode := strings.ToUpper(o.ProcessingMode)
+	switch processingMode {
+	case ProcessingModeStandard, ProcessingModeDisableProblemDetection, ProcessingModeExecutionsDetailsOnly:
+		o.ProcessingMode = processingMode
+		return nil
+	default:
+		return fmt.Errorf("invalid processingMode '%s', must be one of %s, %s or %s", o.ProcessingMode, ProcessingModeStandard, ProcessingModeDisableProblemDetection, ProcessingModeExecutionsDetailsOnly)
+	}
+}
+
+// CustomizedScript customizes the script of a monitor for a single execution
+type CustomizedScript struct {
+	RequestHeaders []RequestHeader          `json:"requestHeaders,omitempty"`
+	Requests       []ScriptUrlCustomization `json:"requests,omitempty"`
+	Events         []ScriptUrlCustomization `json:"events,omitempty"`
+}
+
+// RequestHeader is an HTTP header added to all requests of a customized script
+type RequestHeader struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
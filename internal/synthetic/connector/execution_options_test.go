@@ -0,0 +1,22 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExecutionOptions_Validate(t *testing.T) {
+	options := ExecutionOptions{ProcessingMode: "executions_details_only"}
+	assert.NoError(t, options.Validate())
+	assert.Equal(t, ProcessingModeExecutionsDetailsOnly, options.ProcessingMode)
+
+	options = ExecutionOptions{}
+	assert.NoError(t, options.Validate())
+
+	options = ExecutionOptions{BaseUrl: "pr-42.example.com"}
+	assert.EqualError(t, options.Validate(), "invalid baseUrl 'pr-42.example.com': must be an absolute http or https URL")
+
+	options = ExecutionOptions{ProcessingMode: "fast"}
+	assert.EqualError(t, options.Validate(), "invalid processingMode 'fast', must be one of STANDARD, DISABLE_PROBLEM_DETECTION or EXECUTIONS_DETAILS_ONLY")
+}
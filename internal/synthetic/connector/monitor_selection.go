@@ -0,0 +1,113 @@
+package connector
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+)
+
+var orOperatorRegex = regexp.MustCompile(`\s+OR\s+`)
+var andOperatorRegex = regexp.MustCompile(`\s+AND\s+`)
+
+// MonitorSelection defines the synthetic monitors to be triggered in a single batch.
+type MonitorSelection struct {
+	// MonitorIds are the ids of the monitors to be triggered.
+	MonitorIds []string
+
+	// MonitorTags are tag expressions selecting the monitors to be triggered, e.g. "app:easytravel AND smoke OR critical".
+	// Each tag is specified as "key" or "key:value". AND binds stronger than OR, multiple expressions are combined with OR.
+	MonitorTags []string
+
+	// MonitorNames are exact names of the monitors to be triggered or, if enclosed in slashes, regular expressions matching their names, e.g. "/^easytravel/".
+	MonitorNames []string
+}
+
+// IsEmpty checks whether neither monitor ids nor monitor tags nor monitor names are selected.
+func (s MonitorSelection) IsEmpty() bool {
+	return len(s.MonitorIds) == 0 && len(s.MonitorTags) == 0 && len(s.MonitorNames) == 0
+}
+
+// matchMonitorNames returns the ids of all monitors matching any of the names as well as the names not matching any monitor.
+func (s MonitorSelection) matchMonitorNames(monitors []dynatrace.SyntheticMonitorSummary) ([]string, []string, error) {
+	monitorIds := []string{}
+	unmatchedNames := []string{}
+	for _, name := range s.MonitorNames {
+		matches, err := newMonitorNameMatcher(name)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		isMatched := false
+		for _, monitor := range monitors {
+			if matches(monitor.Name) {
+				monitorIds = AppendUnique(monitorIds, monitor.EntityID)
+				isMatched = true
+			}
+		}
+
+		if !isMatched {
+			unmatchedNames = append(unmatchedNames, name)
+		}
+	}
+
+	return monitorIds, unmatchedNames, nil
+}
+
+// newMonitorNameMatcher creates a function matching the exact name or, if the name is enclosed in slashes, the regular expression.
+func newMonitorNameMatcher(name string) (func(string) bool, error) {
+	if len(name) < 2 || !strings.HasPrefix(name, "/") || !strings.HasSuffix(name, "/") {
+		return func(monitorName string) bool {
+			return monitorName == name
+		}, nil
+	}
+
+	pattern, err := regexp.Compile(name[1 : len(name)-1])
+	if err != nil {
+		return nil, fmt.Errorf("invalid monitor name pattern %s: %w", name, err)
+	}
+
+	return pattern.MatchString, nil
+}
+
+// getTagGroups parses the tag expressions into groups of tags. Monitors having all tags of any group are selected.
+func (s MonitorSelection) getTagGroups() [][]string {
+	tagGroups := [][]string{}
+	for _, expression := range s.MonitorTags {
+		for _, alternative := range orOperatorRegex.Split(strings.TrimSpace(expression), -1) {
+			tagGroup := []string{}
+			for _, tag := range andOperatorRegex.Split(alternative, -1) {
+				tag = strings.TrimSpace(tag)
+				if tag != "" {
+					tagGroup = append(tagGroup, tag)
+				}
+			}
+
+			if len(tagGroup) > 0 {
+				tagGroups = append(tagGroups, tagGroup)
+			}
+		}
+	}
+
+	return tagGroups
+}
+
+// AppendUnique appends the values not yet included in the slice.
+func AppendUnique(slice []string, values ...string) []string {
+	for _, value := range values {
+		isIncluded := false
+		for _, existingValue := range slice {
+			if existingValue == value {
+				isIncluded = true
+				break
+			}
+		}
+
+		if !isIncluded {
+			slice = append(slice, value)
+		}
+	}
+
+	return slice
+}
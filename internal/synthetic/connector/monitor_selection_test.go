@@ -0,0 +1,91 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMonitorSelection_getTagGroups(t *testing.T) {
+	tests := []struct {
+		name        string
+		monitorTags []string
+		want        [][]string
+	}{
+		{
+			name:        "single tag",
+			monitorTags: []string{"smoke"},
+			want:        [][]string{{"smoke"}},
+		},
+		{
+			name:        "key value tags combined with AND",
+			monitorTags: []string{"app:easytravel AND env:prod"},
+			want:        [][]string{{"app:easytravel", "env:prod"}},
+		},
+		{
+			name:        "AND binds stronger than OR",
+			monitorTags: []string{"app:easytravel AND env:prod OR critical"},
+			want:        [][]string{{"app:easytravel", "env:prod"}, {"critical"}},
+		},
+		{
+			name:        "multiple expressions",
+			monitorTags: []string{"app:easytravel", " smoke ", ""},
+			want:        [][]string{{"app:easytravel"}, {"smoke"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, MonitorSelection{MonitorTags: tt.monitorTags}.getTagGroups())
+		})
+	}
+}
+
+func TestMonitorSelection_matchMonitorNames(t *testing.T) {
+	monitors := []dynatrace.SyntheticMonitorSummary{
+		{EntityID: "HTTP_CHECK-1", Name: "easytravel health check"},
+		{EntityID: "SYNTHETIC_TEST-2", Name: "easytravel booking"},
+		{EntityID: "HTTP_CHECK-3", Name: "sockshop health check"},
+	}
+
+	tests := []struct {
+		name               string
+		monitorNames       []string
+		wantMonitorIds     []string
+		wantUnmatchedNames []string
+		wantErr            string
+	}{
+		{
+			name:               "exact names",
+			monitorNames:       []string{"easytravel booking", "easytravel", "sockshop health check"},
+			wantMonitorIds:     []string{"SYNTHETIC_TEST-2", "HTTP_CHECK-3"},
+			wantUnmatchedNames: []string{"easytravel"},
+		},
+		{
+			name:               "regular expressions",
+			monitorNames:       []string{"/^easytravel/", "/health check$/", "/^checkout/"},
+			wantMonitorIds:     []string{"HTTP_CHECK-1", "SYNTHETIC_TEST-2", "HTTP_CHECK-3"},
+			wantUnmatchedNames: []string{"/^checkout/"},
+		},
+		{
+			name:         "invalid regular expression",
+			monitorNames: []string{"/easytravel(/"},
+			wantErr:      "invalid monitor name pattern /easytravel(/",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			monitorIds, unmatchedNames, err := MonitorSelection{MonitorNames: tt.monitorNames}.matchMonitorNames(monitors)
+			if tt.wantErr != "" {
+				if assert.Error(t, err) {
+					assert.Contains(t, err.Error(), tt.wantErr)
+				}
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantMonitorIds, monitorIds)
+			assert.Equal(t, tt.wantUnmatchedNames, unmatchedNames)
+		})
+	}
+}
@@ -0,0 +1,125 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultPollingTimeout is the default maximum duration to wait for synthetic results.
+const DefaultPollingTimeout = 300 * time.Second
+
+// DefaultPollingInterval is the default interval between two requests for synthetic results.
+const DefaultPollingInterval = 10 * time.Second
+
+// DefaultPollingBackoffFactor is the default factor the polling interval is multiplied with after each request.
+const DefaultPollingBackoffFactor = 1.0
+
+// DefaultPollingMaxInterval is the default upper limit for the polling interval.
+const DefaultPollingMaxInterval = 60 * time.Second
+
+// PollingPolicy defines how long and how often the Dynatrace API is polled for synthetic results.
+type PollingPolicy struct {
+	Timeout         time.Duration
+	InitialInterval time.Duration
+	BackoffFactor   float64
+	MaxInterval     time.Duration
+}
+
+// NewDefaultPollingPolicy creates a new PollingPolicy with default values.
+func NewDefaultPollingPolicy() PollingPolicy {
+	return PollingPolicy{
+		Timeout:         DefaultPollingTimeout,
+		InitialInterval: DefaultPollingInterval,
+		BackoffFactor:   DefaultPollingBackoffFactor,
+		MaxInterval:     DefaultPollingMaxInterval,
+	}
+}
+
+// Validate checks that the PollingPolicy can be used for polling or returns an error.
+func (p PollingPolicy) Validate() error {
+	if p.Timeout <= 0 {
+		return fmt.Errorf("polling timeout must be positive: %s", p.Timeout)
+	}
+
+	if p.InitialInterval <= 0 {
+		return fmt.Errorf("polling interval must be positive: %s", p.InitialInterval)
+	}
+
+	if p.BackoffFactor < 1 {
+		return fmt.Errorf("polling backoff factor must be at least 1: %f", p.BackoffFactor)
+	}
+
+	if p.MaxInterval < p.InitialInterval {
+		return fmt.Errorf("polling max interval (%s) must not be smaller than the interval (%s)", p.MaxInterval, p.InitialInterval)
+	}
+
+	return nil
+}
+
+// nextInterval calculates the interval following the specified one, limited by the max interval.
+func (p PollingPolicy) nextInterval(interval time.Duration) time.Duration {
+	next := time.Duration(float64(interval) * p.BackoffFactor)
+	if next > p.MaxInterval {
+		return p.MaxInterval
+	}
+
+	return next
+}
+
+// PollingTimeoutError represents an error that occurs if polling did not succeed within the timeout.
+type PollingTimeoutError struct {
+	timeout time.Duration
+}
+
+// Error returns a string representation of this error.
+func (e *PollingTimeoutError) Error() string {
+	return fmt.Sprintf("could not retrieve data within %f seconds", e.timeout.Seconds())
+}
+
+// poll calls pollFunc until it reports to be done, returns an error, the timeout is exceeded or ctx is done.
+func (p PollingPolicy) poll(ctx context.Context, pollFunc func() (bool, error)) error {
+	err := p.Validate()
+	if err != nil {
+		return err
+	}
+
+	deadline := time.Now().Add(p.Timeout)
+	interval := p.InitialInterval
+
+	for requestCounter := 1; ; requestCounter++ {
+		log.Debug("Requesting data (", requestCounter, ")")
+
+		done, err := pollFunc()
+		if err != nil {
+			return err
+		}
+
+		if done {
+			return nil
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return &PollingTimeoutError{timeout: p.Timeout}
+		}
+
+		wait := interval
+		if wait > remaining {
+			wait = remaining
+		}
+
+		log.Debug("Waiting ", wait.Seconds(), " seconds...")
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return fmt.Errorf("polling interrupted: %w", ctx.Err())
+		case <-timer.C:
+		}
+
+		interval = p.nextInterval(interval)
+	}
+}
@@ -0,0 +1,111 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPollingPolicy_nextInterval(t *testing.T) {
+	policy := PollingPolicy{
+		Timeout:         time.Minute,
+		InitialInterval: 10 * time.Second,
+		BackoffFactor:   2,
+		MaxInterval:     30 * time.Second,
+	}
+
+	assert.Equal(t, 20*time.Second, policy.nextInterval(10*time.Second))
+	assert.Equal(t, 30*time.Second, policy.nextInterval(20*time.Second))
+	assert.Equal(t, 30*time.Second, policy.nextInterval(30*time.Second))
+}
+
+func TestPollingPolicy_Validate(t *testing.T) {
+	assert.NoError(t, NewDefaultPollingPolicy().Validate())
+
+	tests := []struct {
+		name   string
+		policy PollingPolicy
+	}{
+		{
+			name:   "zero timeout",
+			policy: PollingPolicy{Timeout: 0, InitialInterval: time.Second, BackoffFactor: 1, MaxInterval: time.Second},
+		},
+		{
+			name:   "zero interval",
+			policy: PollingPolicy{Timeout: time.Minute, InitialInterval: 0, BackoffFactor: 1, MaxInterval: time.Second},
+		},
+		{
+			name:   "backoff factor smaller than one",
+			policy: PollingPolicy{Timeout: time.Minute, InitialInterval: time.Second, BackoffFactor: 0.5, MaxInterval: time.Second},
+		},
+		{
+			name:   "max interval smaller than interval",
+			policy: PollingPolicy{Timeout: time.Minute, InitialInterval: 2 * time.Second, BackoffFactor: 1, MaxInterval: time.Second},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Error(t, tt.policy.Validate())
+		})
+	}
+}
+
+func TestPollingPolicy_poll(t *testing.T) {
+	policy := PollingPolicy{
+		Timeout:         time.Second,
+		InitialInterval: time.Millisecond,
+		BackoffFactor:   2,
+		MaxInterval:     10 * time.Millisecond,
+	}
+
+	t.Run("done after some requests", func(t *testing.T) {
+		requestCount := 0
+		err := policy.poll(context.Background(), func() (bool, error) {
+			requestCount++
+			return requestCount == 3, nil
+		})
+		assert.NoError(t, err)
+		assert.Equal(t, 3, requestCount)
+	})
+
+	t.Run("error is returned", func(t *testing.T) {
+		err := policy.poll(context.Background(), func() (bool, error) {
+			return false, errors.New("request failed")
+		})
+		assert.EqualError(t, err, "request failed")
+	})
+
+	t.Run("timeout is exceeded", func(t *testing.T) {
+		timeoutPolicy := policy
+		timeoutPolicy.Timeout = 20 * time.Millisecond
+
+		err := timeoutPolicy.poll(context.Background(), func() (bool, error) {
+			return false, nil
+		})
+		var timeoutError *PollingTimeoutError
+		assert.ErrorAs(t, err, &timeoutError)
+	})
+
+	t.Run("context is cancelled", func(t *testing.T) {
+		cancelPolicy := policy
+		cancelPolicy.InitialInterval = time.Minute
+		cancelPolicy.MaxInterval = time.Minute
+		cancelPolicy.Timeout = time.Hour
+
+		ctx, cancel := context.WithCancel(context.Background())
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			cancel()
+		}()
+
+		start := time.Now()
+		err := cancelPolicy.poll(ctx, func() (bool, error) {
+			return false, nil
+		})
+		assert.ErrorIs(t, err, context.Canceled)
+		assert.Less(t, time.Since(start), time.Second)
+	})
+}
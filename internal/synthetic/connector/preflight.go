@@ -0,0 +1,110 @@
+package connector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+)
+
+// ResolveMonitors resolves the selected monitor ids, tags and names to the selected monitors, including their enabled state and locations.
+// Monitor ids which do not exist as well as tags and names which do not match any monitor are reported together in a single error.
+func (sc *SyntheticConnector) ResolveMonitors(workCtx context.Context, selection MonitorSelection) ([]dynatrace.SyntheticMonitor, error) {
+	monitorsClient := dynatrace.NewSyntheticMonitorsClient(sc.dtClient)
+
+	problems := []string{}
+	monitorIds := AppendUnique([]string{}, selection.MonitorIds...)
+	for _, tagGroup := range selection.getTagGroups() {
+		monitors, err := monitorsClient.GetByTags(workCtx, tagGroup)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve synthetic monitors with tags %s: %w", strings.Join(tagGroup, " AND "), err)
+		}
+
+		if len(monitors) == 0 {
+			problems = append(problems, fmt.Sprintf("no monitors found for tags %s", strings.Join(tagGroup, " AND ")))
+		}
+
+		for _, monitor := range monitors {
+			monitorIds = AppendUnique(monitorIds, monitor.EntityID)
+		}
+	}
+
+	monitorIdsByName, unmatchedNames, err := sc.resolveMonitorNames(workCtx, selection)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range unmatchedNames {
+		problems = append(problems, fmt.Sprintf("no monitors found for name %s", name))
+	}
+	monitorIds = AppendUnique(monitorIds, monitorIdsByName...)
+
+	monitors := make([]dynatrace.SyntheticMonitor, 0, len(monitorIds))
+	for _, monitorId := range monitorIds {
+		monitor, err := monitorsClient.GetByID(workCtx, monitorId)
+		if err != nil {
+			var apiErr *dynatrace.APIError
+			if errors.As(err, &apiErr) && (apiErr.Code() == http.StatusNotFound || apiErr.Code() == http.StatusBadRequest) {
+				problems = append(problems, fmt.Sprintf("monitor %s was not found", monitorId))
+				continue
+			}
+
+			return nil, fmt.Errorf("could not retrieve synthetic monitor %s: %w", monitorId, err)
+		}
+
+		monitors = append(monitors, *monitor)
+	}
+
+	if len(problems) > 0 {
+		return nil, newPreflightError(problems, monitors)
+	}
+
+	return monitors, nil
+}
+
+// CheckMonitors checks that all monitors are enabled and have locations assigned and returns the ids of the disabled monitors.
+// If disabled monitors are allowed, e.g. because they are enabled for the batch, they are not reported as a problem.
+func CheckMonitors(monitors []dynatrace.SyntheticMonitor, allowDisabled bool) ([]string, error) {
+	problems := []string{}
+	disabledMonitorIds := []string{}
+	for _, monitor := range monitors {
+		if !monitor.Enabled {
+			disabledMonitorIds = append(disabledMonitorIds, monitor.EntityID)
+			if !allowDisabled {
+				problems = append(problems, fmt.Sprintf("monitor %s is disabled", formatMonitor(monitor)))
+			}
+		}
+
+		if len(monitor.Locations) == 0 {
+			problems = append(problems, fmt.Sprintf("monitor %s has no locations", formatMonitor(monitor)))
+		}
+	}
+
+	if len(problems) > 0 {
+		return nil, newPreflightError(problems, monitors)
+	}
+
+	return disabledMonitorIds, nil
+}
+
+// newPreflightError lists the problems as well as all monitors found, so that a wrong selection can be spotted in the finished event
+func newPreflightError(problems []string, monitors []dynatrace.SyntheticMonitor) error {
+	foundMonitors := make([]string, 0, len(monitors))
+	for _, monitor := range monitors {
+		foundMonitors = append(foundMonitors, fmt.Sprintf("%s (enabled: %t, locations: %d)", formatMonitor(monitor), monitor.Enabled, len(monitor.Locations)))
+	}
+
+	found := "none"
+	if len(foundMonitors) > 0 {
+		found = strings.Join(foundMonitors, ", ")
+	}
+
+	return fmt.Errorf("synthetic monitors failed the pre-flight check: %s. Found monitors: %s", strings.Join(problems, ", "), found)
+}
+
+func formatMonitor(monitor dynatrace.SyntheticMonitor) string {
+	return fmt.Sprintf("%s '%s'", monitor.EntityID, monitor.Name)
+}
@@ -0,0 +1,59 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResolveMonitors(t *testing.T) {
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/api/v1/synthetic/monitors":
+			if r.URL.Query().Get("tag") == "app:easytravel" {
+				_, _ = w.Write([]byte(`{"monitors":[{"entityId":"SYNTHETIC_TEST-2","name":"booking","type":"BROWSER","enabled":false}]}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"monitors":[]}`))
+		case "/api/v1/synthetic/monitors/HTTP_CHECK-1":
+			_, _ = w.Write([]byte(`{"entityId":"HTTP_CHECK-1","name":"health check","type":"HTTP","enabled":true,"locations":["GEOLOCATION-1"]}`))
+		case "/api/v1/synthetic/monitors/SYNTHETIC_TEST-2":
+			_, _ = w.Write([]byte(`{"entityId":"SYNTHETIC_TEST-2","name":"booking","type":"BROWSER","enabled":false,"locations":[]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			_, _ = w.Write([]byte(`{"error":{"code":404,"message":"Monitor not found"}}`))
+		}
+	})
+	sc, teardown := createSyntheticConnector(t, handler)
+	defer teardown()
+
+	monitors, err := sc.ResolveMonitors(context.TODO(), MonitorSelection{MonitorIds: []string{"HTTP_CHECK-1"}, MonitorTags: []string{"app:easytravel"}})
+	assert.NoError(t, err)
+	assert.Equal(t, []dynatrace.SyntheticMonitor{
+		{EntityID: "HTTP_CHECK-1", Name: "health check", Type: "HTTP", Enabled: true, Locations: []string{"GEOLOCATION-1"}},
+		{EntityID: "SYNTHETIC_TEST-2", Name: "booking", Type: "BROWSER", Enabled: false, Locations: []string{}},
+	}, monitors)
+
+	_, err = sc.ResolveMonitors(context.TODO(), MonitorSelection{MonitorIds: []string{"HTTP_CHECK-1", "HTTP_CHECK-9"}, MonitorTags: []string{"smoke"}})
+	assert.EqualError(t, err, "synthetic monitors failed the pre-flight check: no monitors found for tags smoke, monitor HTTP_CHECK-9 was not found. Found monitors: HTTP_CHECK-1 'health check' (enabled: true, locations: 1)")
+}
+
+func TestCheckMonitors(t *testing.T) {
+	monitors := []dynatrace.SyntheticMonitor{
+		{EntityID: "HTTP_CHECK-1", Name: "health check", Enabled: true, Locations: []string{"GEOLOCATION-1"}},
+		{EntityID: "SYNTHETIC_TEST-2", Name: "booking", Enabled: false, Locations: []string{"GEOLOCATION-1", "GEOLOCATION-2"}},
+	}
+
+	_, err := CheckMonitors(monitors, false)
+	assert.EqualError(t, err, "synthetic monitors failed the pre-flight check: monitor SYNTHETIC_TEST-2 'booking' is disabled. Found monitors: HTTP_CHECK-1 'health check' (enabled: true, locations: 1), SYNTHETIC_TEST-2 'booking' (enabled: false, locations: 2)")
+
+	disabledMonitorIds, err := CheckMonitors(monitors, true)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"SYNTHETIC_TEST-2"}, disabledMonitorIds)
+
+	_, err = CheckMonitors([]dynatrace.SyntheticMonitor{{EntityID: "HTTP_CHECK-3", Name: "login", Enabled: true}}, true)
+	assert.EqualError(t, err, "synthetic monitors failed the pre-flight check: monitor HTTP_CHECK-3 'login' has no locations. Found monitors: HTTP_CHECK-3 'login' (enabled: true, locations: 0)")
+}
@@ -0,0 +1,56 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+)
+
+// monitoredEntityTypes are the types of entities synthetic monitors are related to, i.e. applications of browser monitors and services of HTTP monitors
+var monitoredEntityTypes = []string{"APPLICATION", "SERVICE"}
+
+// monitorEntityTypes are the entity types of browser monitors and HTTP monitors
+var monitorEntityTypes = []string{"SYNTHETIC_TEST", "HTTP_CHECK"}
+
+// createRelatedMonitorsEntitySelector creates an entity selector for monitors of the specified type monitoring entities of the specified type having the Keptn tags of the project, stage and service
+func createRelatedMonitorsEntitySelector(monitorEntityType string, monitoredEntityType string, project string, stage string, service string) string {
+	tags := []string{
+		fmt.Sprintf(`tag("keptn_project:%s")`, escapeEntitySelectorValue(project)),
+		fmt.Sprintf(`tag("keptn_stage:%s")`, escapeEntitySelectorValue(stage)),
+		fmt.Sprintf(`tag("keptn_service:%s")`, escapeEntitySelectorValue(service)),
+	}
+
+	return fmt.Sprintf(`type("%s"),fromRelationships.monitors(type("%s"),%s)`, monitorEntityType, monitoredEntityType, strings.Join(tags, ","))
+}
+
+// escapeEntitySelectorValue escapes quotes and tildes within a quoted entity selector value by prefixing them with a tilde
+func escapeEntitySelectorValue(value string) string {
+	return strings.NewReplacer(`~`, `~~`, `"`, `~"`).Replace(value)
+}
+
+// FindRelatedMonitors finds the ids of the synthetic monitors monitoring the applications or services tagged with the Keptn project, stage and service.
+func (sc *SyntheticConnector) FindRelatedMonitors(workCtx context.Context, project string, stage string, service string) ([]string, error) {
+	entitiesClient := dynatrace.NewEntitiesClient(sc.dtClient)
+
+	monitorIds := []string{}
+	for _, monitorEntityType := range monitorEntityTypes {
+		for _, monitoredEntityType := range monitoredEntityTypes {
+			entities, err := entitiesClient.GetByEntitySelector(workCtx, createRelatedMonitorsEntitySelector(monitorEntityType, monitoredEntityType, project, stage, service))
+			if err != nil {
+				return nil, fmt.Errorf("could not retrieve synthetic monitors related to entities of type %s: %w", monitoredEntityType, err)
+			}
+
+			for _, entity := range entities {
+				monitorIds = AppendUnique(monitorIds, entity.EntityID)
+			}
+		}
+	}
+
+	if len(monitorIds) == 0 {
+		return nil, fmt.Errorf("no synthetic monitors found monitoring applications or services tagged with keptn_project:%s, keptn_stage:%s and keptn_service:%s", project, stage, service)
+	}
+
+	return monitorIds, nil
+}
@@ -0,0 +1,46 @@
+package connector
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFindRelatedMonitors(t *testing.T) {
+	requestedEntitySelectors := []string{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/entities", r.URL.Path)
+
+		entitySelector := r.URL.Query().Get("entitySelector")
+		requestedEntitySelectors = append(requestedEntitySelectors, entitySelector)
+
+		switch entitySelector {
+		case `type("SYNTHETIC_TEST"),fromRelationships.monitors(type("APPLICATION"),tag("keptn_project:easytravel"),tag("keptn_stage:staging"),tag("keptn_service:frontend"))`:
+			_, _ = w.Write([]byte(`{"totalCount":1,"pageSize":50,"entities":[{"entityId":"SYNTHETIC_TEST-1","displayName":"booking"}]}`))
+		case `type("HTTP_CHECK"),fromRelationships.monitors(type("APPLICATION"),tag("keptn_project:easytravel"),tag("keptn_stage:staging"),tag("keptn_service:frontend"))`:
+			_, _ = w.Write([]byte(`{"totalCount":1,"pageSize":50,"entities":[{"entityId":"HTTP_CHECK-2","displayName":"health check"}]}`))
+		case `type("HTTP_CHECK"),fromRelationships.monitors(type("SERVICE"),tag("keptn_project:easytravel"),tag("keptn_stage:staging"),tag("keptn_service:frontend"))`:
+			_, _ = w.Write([]byte(`{"totalCount":1,"pageSize":50,"entities":[{"entityId":"HTTP_CHECK-2","displayName":"health check"}]}`))
+		default:
+			_, _ = w.Write([]byte(`{"totalCount":0,"pageSize":50,"entities":[]}`))
+		}
+	})
+	sc, teardown := createSyntheticConnector(t, handler)
+	defer teardown()
+
+	monitorIds, err := sc.FindRelatedMonitors(context.TODO(), "easytravel", "staging", "frontend")
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"SYNTHETIC_TEST-1", "HTTP_CHECK-2"}, monitorIds)
+	assert.Len(t, requestedEntitySelectors, 4)
+
+	_, err = sc.FindRelatedMonitors(context.TODO(), "easytravel", "staging", "backend")
+	assert.EqualError(t, err, "no synthetic monitors found monitoring applications or services tagged with keptn_project:easytravel, keptn_stage:staging and keptn_service:backend")
+}
+
+func TestCreateRelatedMonitorsEntitySelector(t *testing.T) {
+	entitySelector := createRelatedMonitorsEntitySelector("HTTP_CHECK", "SERVICE", `easy"travel`, "staging~1", "frontend")
+	assert.Equal(t, `type("HTTP_CHECK"),fromRelationships.monitors(type("SERVICE"),tag("keptn_project:easy~"travel"),tag("keptn_stage:staging~~1"),tag("keptn_service:frontend"))`, entitySelector)
+}
@@ -3,32 +3,70 @@ package connector
 import (
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"math"
+	"strings"
 	"time"
 
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/sli/metrics"
 	log "github.com/sirupsen/logrus"
 )
 
-const syntheticBatchBasePath = "/api/v2/synthetic/executions/batch"
-const metricsIngestPath = "/api/v2/metrics/ingest"
+const syntheticExecutionsBasePath = "/api/v2/synthetic/executions"
+const syntheticBatchBasePath = syntheticExecutionsBasePath + "/batch"
 
 const executionSuccessMetricKey = "ca.synthetic.execution_success_rate"
+const executionFailedStepsMetricKey = "ca.synthetic.execution_failed_steps"
+const executionDurationMetricKey = "ca.synthetic.execution_duration"
+
+// SyntheticDataRequiredDelay is the delay required between the execution of synthetic monitors and a Metrics V2 API request for their builtin:synthetic.* metrics.
+// It must not be shorter than dynatrace.MetricsRequiredDelay, which is applied to each request.
+const SyntheticDataRequiredDelay = 3 * time.Minute
+
+// SyntheticDataMaximumWait is the maximum acceptable wait time between the execution of synthetic monitors and a Metrics V2 API request for their builtin:synthetic.* metrics.
+const SyntheticDataMaximumWait = 5 * time.Minute
+
+const httpMonitorIdPrefix = "HTTP_CHECK-"
+const browserMonitorIdPrefix = "SYNTHETIC_TEST-"
+
+const publicLocationIdPrefix = "GEOLOCATION-"
+const privateLocationIdPrefix = "SYNTHETIC_LOCATION-"
+
+const httpAvailabilityMetricKey = "builtin:synthetic.http.availability.location.total"
+const browserAvailabilityMetricKey = "builtin:synthetic.browser.availability.location.total"
+
+const httpMonitorDimensionKey = "dt.entity.http_check"
+const browserMonitorDimensionKey = "dt.entity.synthetic_test"
+const locationDimensionKey = "dt.entity.synthetic_location"
+
+// IsMonitorId checks whether the id is the id of a Dynatrace HTTP or browser monitor rather than e.g. of a third-party synthetic monitor.
+func IsMonitorId(id string) bool {
+	return strings.HasPrefix(id, httpMonitorIdPrefix) || strings.HasPrefix(id, browserMonitorIdPrefix)
+}
 
 func getSyntheticBatchPath(batchId string) string {
 	return fmt.Sprintf("%s/%s", syntheticBatchBasePath, batchId)
 }
 
+func getSyntheticExecutionFullReportPath(executionId string) string {
+	return fmt.Sprintf("%s/%s/fullReport", syntheticExecutionsBasePath, executionId)
+}
+
+// SyntheticConnectorInterface triggers batches of synthetic monitors. It does not keep any state of the triggered batches, so it can be shared by concurrently handled events.
 type SyntheticConnectorInterface interface {
-	TriggerById(workCtx context.Context, monitorId string) (ExecutionData, error)
-	TriggerByTag(workCtx context.Context, monitorTag string) (ExecutionData, error)
-	WaitForBatchExecution(workCtx context.Context) (BatchResponseBody, float64, error)
+	ResolveMonitors(workCtx context.Context, selection MonitorSelection) ([]dynatrace.SyntheticMonitor, error)
+	FindRelatedMonitors(workCtx context.Context, project string, stage string, service string) ([]string, error)
+	Trigger(workCtx context.Context, selection MonitorSelection, locations []string, options ExecutionOptions) (Batch, error)
+	RestoreBatch(state TriggeredBatch) Batch
+	WaitForData(workCtx context.Context, monitorIds []string, startTime time.Time, policy PollingPolicy) error
+	IngestSyntheticMetrics(workCtx context.Context, executionData ExecutionData, projectName string, serviceName string, stageName string) (dynatrace.MetricsIngestResponse, error)
 }
 
 type SyntheticConnector struct {
-	dtClient      dynatrace.ClientInterface
-	executionData ExecutionData
+	dtClient dynatrace.ClientInterface
 }
 
 type ExecutionResponseBody struct {
@@ -54,6 +92,27 @@ type ExecutionTriggered struct {
 }
 
 type ExecutionData struct {
+	BatchId             string                   `json:"batchId"`
+	MonitorIds          []string                 `json:"monitorIds"`
+	ExecutionIds        []string                 `json:"executionIds"`
+	TriggeredExecutions []TriggeredExecution     `json:"triggeredExecutions"`
+	FailedTriggers      []ExecutionNotTriggered  `json:"failedTriggers"`
+	FailedExecutions    []ExecutionNotSuccessful `json:"failedExecutions"`
+	SuccessRate         float64                  `json:"successRate"`
+	Executions          []ExecutionReport        `json:"executions"`
+	Attempts            []ExecutionAttempt       `json:"attempts"`
+	UrlOverrides        []UrlOverride            `json:"urlOverrides"`
+}
+
+// TriggeredExecution identifies a single triggered execution of a monitor at a location
+type TriggeredExecution struct {
+	ExecutionId string `json:"executionId"`
+	MonitorId   string `json:"monitorId"`
+	LocationId  string `json:"locationId"`
+}
+
+// ExecutionAttempt summarizes a single batch of a triggered or re-triggered synthetic test
+type ExecutionAttempt struct {
 	BatchId          string                   `json:"batchId"`
 	ExecutionIds     []string                 `json:"executionIds"`
 	FailedTriggers   []ExecutionNotTriggered  `json:"failedTriggers"`
@@ -61,6 +120,12 @@ type ExecutionData struct {
 	SuccessRate      float64                  `json:"successRate"`
 }
 
+// MonitorLocation identifies a monitor at a location. An empty location id refers to all locations the monitor was originally triggered for.
+type MonitorLocation struct {
+	MonitorId  string
+	LocationId string
+}
+
 type ExecutionNotSuccessful struct {
 	ExecutionId        string `json:"executionId"`
 	ExecutionStage     string `json:"executionStage"`
@@ -69,6 +134,48 @@ type ExecutionNotSuccessful struct {
 	LocationId         string `json:"locationId"`
 }
 
+// ExecutionReport is the full report of a single synthetic execution
+type ExecutionReport struct {
+	ExecutionId        string                 `json:"executionId"`
+	MonitorId          string                 `json:"monitorId"`
+	LocationId         string                 `json:"locationId"`
+	ExecutionStage     string                 `json:"executionStage"`
+	ExecutionTimestamp int64                  `json:"executionTimestamp"`
+	SimpleResults      ExecutionSimpleResults `json:"simpleResults"`
+	FullResults        ExecutionFullResults   `json:"fullResults"`
+}
+
+// ExecutionSimpleResults summarizes the result of a synthetic execution
+type ExecutionSimpleResults struct {
+	StartTimestamp       int64  `json:"startTimestamp"`
+	Status               string `json:"status"`
+	ErrorCode            string `json:"errorCode,omitempty"`
+	FailureMessage       string `json:"failureMessage,omitempty"`
+	FailedStepName       string `json:"failedStepName,omitempty"`
+	FailedStepSequenceId int    `json:"failedStepSequenceId,omitempty"`
+	ResponseStatusCode   int    `json:"responseStatusCode,omitempty"`
+	Duration             int64  `json:"duration,omitempty"`
+}
+
+// ExecutionFullResults contains the results of all steps of a synthetic execution
+type ExecutionFullResults struct {
+	ExecutionSteps []ExecutionStepResult `json:"executionSteps"`
+}
+
+// ExecutionStepResult is the result of a single step (browser monitors) or request (HTTP monitors) of a synthetic execution
+type ExecutionStepResult struct {
+	StepId             int    `json:"stepId,omitempty"`
+	StepName           string `json:"stepName,omitempty"`
+	RequestId          string `json:"requestId,omitempty"`
+	RequestName        string `json:"requestName,omitempty"`
+	StartTimestamp     int64  `json:"startTimestamp"`
+	Status             string `json:"status"`
+	ResponseStatusCode int    `json:"responseStatusCode,omitempty"`
+	ResponseTime       int64  `json:"responseTime,omitempty"`
+	ErrorCode          string `json:"errorCode,omitempty"`
+	FailureMessage     string `json:"failureMessage,omitempty"`
+}
+
 type BatchResponseBody struct {
 	BatchStatus          string                   `json:"batchStatus"`
 	TriggeredCount       int                      `json:"triggeredCount"`
@@ -78,34 +185,109 @@ type BatchResponseBody struct {
 	FailedExecutions     []ExecutionNotSuccessful `json:"failedExecutions"`
 }
 
-type IngestResponseBody struct {
-	LinesOk      int `json:"linesOk"`
-	LinesInvalid int `json:"linesInvalid"`
+// ExecutionRequestBody is the request body for triggering a batch of synthetic executions
+type ExecutionRequestBody struct {
+	Monitors                 []MonitorExecutionRequest `json:"monitors,omitempty"`
+	Group                    *GroupExecutionRequest    `json:"group,omitempty"`
+	ProcessingMode           string                    `json:"processingMode,omitempty"`
+	FailOnPerformanceIssue   *bool                     `json:"failOnPerformanceIssue,omitempty"`
+	FailOnSslWarning         *bool                     `json:"failOnSslWarning,omitempty"`
+	StopOnProblem            *bool                     `json:"stopOnProblem,omitempty"`
+	TakeScreenshotsOnSuccess *bool                     `json:"takeScreenshotsOnSuccess,omitempty"`
 }
 
-func generateExecutionByIdEvent(monitorId string) []byte {
-	jsonData := []byte(fmt.Sprintf(`{
-		"monitors": [
-			{
-				"monitorId": "%s",
-				"locations": []
-			}
-		]
-	}`, monitorId))
+// MonitorExecutionRequest defines the execution of a single monitor, optionally restricted to the specified locations
+type MonitorExecutionRequest struct {
+	MonitorId        string            `json:"monitorId"`
+	Locations        []string          `json:"locations"`
+	CustomizedScript *CustomizedScript `json:"customizedScript,omitempty"`
+}
+
+// GroupExecutionRequest defines the execution of all monitors matching the tags, optionally restricted to the specified locations
+type GroupExecutionRequest struct {
+	Tags      []string `json:"tags"`
+	Locations []string `json:"locations,omitempty"`
+}
+
+func generateExecutionByIdsEvent(monitorIds []string, locationIds []string, options ExecutionOptions, urlOverrides []UrlOverride) ([]byte, error) {
+	monitors := make([]MonitorExecutionRequest, 0, len(monitorIds))
+	for _, monitorId := range monitorIds {
+		monitors = append(monitors, MonitorExecutionRequest{
+			MonitorId:        monitorId,
+			Locations:        nonNilLocationIds(locationIds),
+			CustomizedScript: getCustomizedScript(monitorId, options, urlOverrides),
+		})
+	}
+
+	return json.Marshal(newExecutionRequestBody(options, monitors, nil))
+}
+
+// generateExecutionByTagEvent generates a request body triggering all monitors with the tag. Customized scripts and URL overrides are only applied to monitors triggered by id.
+func generateExecutionByTagEvent(monitorTag string, locationIds []string, options ExecutionOptions) ([]byte, error) {
+	group := &GroupExecutionRequest{
+		Tags:      []string{monitorTag},
+		Locations: locationIds,
+	}
+
+	return json.Marshal(newExecutionRequestBody(options, nil, group))
+}
 
-	return jsonData
+func newExecutionRequestBody(options ExecutionOptions, monitors []MonitorExecutionRequest, group *GroupExecutionRequest) ExecutionRequestBody {
+	return ExecutionRequestBody{
+		Monitors:                 monitors,
+		Group:                    group,
+		ProcessingMode:           options.ProcessingMode,
+		FailOnPerformanceIssue:   options.FailOnPerformanceIssue,
+		FailOnSslWarning:         options.FailOnSslWarning,
+		StopOnProblem:            options.StopOnProblem,
+		TakeScreenshotsOnSuccess: options.TakeScreenshotsOnSuccess,
+	}
 }
 
-func generateExecutionByTagEvent(monitorTag string) []byte {
-	jsonData := []byte(fmt.Sprintf(`{
-		"group": {
-			"tags": [
-				"%s"
-			]
+// generateExecutionByMonitorLocationsEvent generates a request body triggering each monitor at its specified locations.
+// If any pair of a monitor has no location id, the monitor is triggered at the default location ids instead.
+func generateExecutionByMonitorLocationsEvent(monitorLocations []MonitorLocation, defaultLocationIds []string, options ExecutionOptions, urlOverrides []UrlOverride) ([]byte, error) {
+	monitorIds := []string{}
+	locationIdsByMonitorId := make(map[string][]string)
+	allLocationsByMonitorId := make(map[string]bool)
+	for _, monitorLocation := range monitorLocations {
+		if _, found := locationIdsByMonitorId[monitorLocation.MonitorId]; !found {
+			monitorIds = append(monitorIds, monitorLocation.MonitorId)
+			locationIdsByMonitorId[monitorLocation.MonitorId] = []string{}
+		}
+
+		if monitorLocation.LocationId == "" {
+			allLocationsByMonitorId[monitorLocation.MonitorId] = true
+			continue
 		}
-	}`, monitorTag))
 
-	return jsonData
+		locationIdsByMonitorId[monitorLocation.MonitorId] = AppendUnique(locationIdsByMonitorId[monitorLocation.MonitorId], monitorLocation.LocationId)
+	}
+
+	monitors := make([]MonitorExecutionRequest, 0, len(monitorIds))
+	for _, monitorId := range monitorIds {
+		locationIds := locationIdsByMonitorId[monitorId]
+		if allLocationsByMonitorId[monitorId] {
+			locationIds = nonNilLocationIds(defaultLocationIds)
+		}
+
+		monitors = append(monitors, MonitorExecutionRequest{
+			MonitorId:        monitorId,
+			Locations:        locationIds,
+			CustomizedScript: getCustomizedScript(monitorId, options, urlOverrides),
+		})
+	}
+
+	return json.Marshal(newExecutionRequestBody(options, monitors, nil))
+}
+
+// nonNilLocationIds ensures that an empty list of locations is sent instead of null, i.e. all locations of the monitor are used.
+func nonNilLocationIds(locationIds []string) []string {
+	if locationIds == nil {
+		return []string{}
+	}
+
+	return locationIds
 }
 
 func parseExecutionIds(executionResponseBody ExecutionResponseBody) []string {
@@ -120,6 +302,32 @@ func parseExecutionIds(executionResponseBody ExecutionResponseBody) []string {
 	return executionIds
 }
 
+func parseTriggeredExecutions(executionResponseBody ExecutionResponseBody) []TriggeredExecution {
+	triggeredExecutions := []TriggeredExecution{}
+
+	for _, triggered := range executionResponseBody.Triggered {
+		for _, execution := range triggered.Executions {
+			triggeredExecutions = append(triggeredExecutions, TriggeredExecution{
+				ExecutionId: execution.ExecutionId,
+				MonitorId:   triggered.MonitorId,
+				LocationId:  execution.LocationId,
+			})
+		}
+	}
+
+	return triggeredExecutions
+}
+
+func parseMonitorIds(executionResponseBody ExecutionResponseBody) []string {
+	monitorIds := []string{}
+
+	for _, triggered := range executionResponseBody.Triggered {
+		monitorIds = append(monitorIds, triggered.MonitorId)
+	}
+
+	return monitorIds
+}
+
 func parseFailedTriggers(executionResponseBody ExecutionResponseBody) []ExecutionNotTriggered {
 	executions := executionResponseBody.TriggeringProblemsDetails
 	return executions
@@ -130,94 +338,196 @@ func parseBatchId(executionResponseBody ExecutionResponseBody) string {
 	return batchId
 }
 
-func (sc *SyntheticConnector) TriggerById(workCtx context.Context, monitorId string) (ExecutionData, error) {
-	jsonData := generateExecutionByIdEvent(monitorId)
-
-	log.Debug("TriggerById")
-	log.Debug(string(jsonData))
+// Trigger triggers all selected monitors in a single batch with the specified options, optionally restricted to the specified location ids or names.
+func (sc *SyntheticConnector) Trigger(workCtx context.Context, selection MonitorSelection, locations []string, options ExecutionOptions) (Batch, error) {
+	if selection.IsEmpty() {
+		return nil, errors.New("neither monitor ids nor monitor tags nor monitor names are selected")
+	}
 
-	return sc.trigger(workCtx, jsonData)
-}
+	locationIds, err := sc.resolveLocationIds(workCtx, locations)
+	if err != nil {
+		return nil, err
+	}
 
-func (sc *SyntheticConnector) TriggerByTag(workCtx context.Context, monitorTag string) (ExecutionData, error) {
-	jsonData := generateExecutionByTagEvent(monitorTag)
+	jsonData, urlOverrides, err := sc.generateExecutionEvent(workCtx, selection, locationIds, options)
+	if err != nil {
+		return nil, err
+	}
 
-	log.Debug("TriggerByTag")
+	log.Debug("Trigger")
 	log.Debug(string(jsonData))
 
-	return sc.trigger(workCtx, jsonData)
+	return triggerBatch(workCtx, sc.dtClient, jsonData, locationIds, options, urlOverrides)
 }
 
-func (sc *SyntheticConnector) trigger(workCtx context.Context, jsonData []byte) (ExecutionData, error) {
-	resp, err := sc.dtClient.Post(workCtx, syntheticBatchBasePath, jsonData)
+// RestoreBatch restores a previously triggered batch from its state, e.g. to resume waiting for it after a restart.
+func (sc *SyntheticConnector) RestoreBatch(state TriggeredBatch) Batch {
+	return &SyntheticBatch{
+		dtClient: sc.dtClient,
+		state:    state,
+	}
+}
+
+// WaitForData waits for the results of the monitors executed since the start time, e.g. in all batches of a test, to be available as builtin:synthetic.* metrics.
+// The metrics are first queried once the required delay has passed since the execution and then polled until they are available for all monitors.
+//
+// Attention: Expects the batches to be executed, i.e. Batch.Wait should be called first
+func (sc *SyntheticConnector) WaitForData(workCtx context.Context, monitorIds []string, startTime time.Time, policy PollingPolicy) error {
+	timeframe, err := common.NewTimeframe(startTime, time.Now().UTC())
 	if err != nil {
-		return ExecutionData{}, err
+		return err
 	}
 
-	executionResponseBody := ExecutionResponseBody{}
-	err = json.Unmarshal(resp, &executionResponseBody)
+	dataQueries, err := createSyntheticDataQueries(monitorIds)
 	if err != nil {
-		log.Error(err.Error())
-		return ExecutionData{}, err
+		return err
 	}
 
-	sc.executionData.BatchId = parseBatchId(executionResponseBody)
-	sc.executionData.ExecutionIds = parseExecutionIds(executionResponseBody)
-	sc.executionData.FailedTriggers = parseFailedTriggers(executionResponseBody)
+	err = dynatrace.NewTimeframeDelay(*timeframe, SyntheticDataRequiredDelay, SyntheticDataMaximumWait).Wait(workCtx)
+	if err != nil {
+		return err
+	}
 
-	return sc.executionData, nil
-}
+	metricsClient := dynatrace.NewMetricsClient(sc.dtClient)
+
+	return policy.poll(workCtx, func() (bool, error) {
+		pendingDataQueries := []syntheticDataQuery{}
+		for _, dataQuery := range dataQueries {
+			result, err := metricsClient.GetByQuery(workCtx, dynatrace.NewMetricsClientQueryParameters(dataQuery.query, *timeframe))
+			if err != nil {
+				return false, err
+			}
+
+			if !hasDataForAllMonitors(dataQuery, result) {
+				pendingDataQueries = append(pendingDataQueries, dataQuery)
+			}
+		}
 
-func generateMetricsIngestLine(syntheticTestId string, projectName string, serviceName string, stageName string, batchId string, gauge float64) string {
-	return fmt.Sprintf(
-		"%s,dt.entity.synthetic_test=%s,ca.project.name=%s,ca.service.name=%s,ca.stage.name=%s,ca.synthetic.batch_id=%s gauge,%f",
-		executionSuccessMetricKey,
-		syntheticTestId,
-		projectName,
-		serviceName,
-		stageName,
-		batchId,
-		gauge,
-	)
+		dataQueries = pendingDataQueries
+		return len(dataQueries) == 0, nil
+	})
 }
 
-func (sc *SyntheticConnector) IngestSyntheticSuccessMetric(workCtx context.Context, syntheticTestId string, projectName string, serviceName string, stageName string, batchId string, gauge float64) (IngestResponseBody, error) {
-	postData := []byte(generateMetricsIngestLine(syntheticTestId, projectName, serviceName, stageName, batchId, gauge))
+// generateExecutionEvent generates the batch request body for the selection.
+// A single tag is triggered as a group unless a customized script or base URL is used, otherwise all tag groups are resolved to monitor ids which are triggered together with the selected monitor ids.
+// If a base URL is used, the URL overrides of all monitors are returned for re-triggering and reporting.
+func (sc *SyntheticConnector) generateExecutionEvent(workCtx context.Context, selection MonitorSelection, locationIds []string, options ExecutionOptions) ([]byte, []UrlOverride, error) {
+	tagGroups := selection.getTagGroups()
+	if len(selection.MonitorIds) == 0 && len(selection.MonitorNames) == 0 && len(tagGroups) == 1 && len(tagGroups[0]) == 1 && options.CustomizedScript == nil && options.BaseUrl == "" {
+		jsonData, err := generateExecutionByTagEvent(tagGroups[0][0], locationIds, options)
+		return jsonData, nil, err
+	}
+
+	monitorIds := AppendUnique([]string{}, selection.MonitorIds...)
+
+	monitorsClient := dynatrace.NewSyntheticMonitorsClient(sc.dtClient)
+	for _, tagGroup := range tagGroups {
+		monitors, err := monitorsClient.GetByTags(workCtx, tagGroup)
+		if err != nil {
+			return nil, nil, fmt.Errorf("could not retrieve synthetic monitors with tags %s: %w", strings.Join(tagGroup, " AND "), err)
+		}
+
+		for _, monitor := range monitors {
+			monitorIds = AppendUnique(monitorIds, monitor.EntityID)
+		}
+	}
 
-	resp, err := sc.dtClient.PostTextPlain(workCtx, metricsIngestPath, postData)
+	monitorIdsByName, _, err := sc.resolveMonitorNames(workCtx, selection)
 	if err != nil {
-		return IngestResponseBody{}, err
+		return nil, nil, err
 	}
+	monitorIds = AppendUnique(monitorIds, monitorIdsByName...)
 
-	log.Debug(string(resp))
+	if len(monitorIds) == 0 {
+		return nil, nil, fmt.Errorf("no synthetic monitors found for tags: %s", strings.Join(selection.MonitorTags, ", "))
+	}
 
-	ingestResponseBody := IngestResponseBody{}
-	err = json.Unmarshal(resp, &ingestResponseBody)
-	if err != nil {
-		log.Error(err.Error())
-		return IngestResponseBody{}, err
+	var urlOverrides []UrlOverride
+	if options.BaseUrl != "" {
+		urlOverrides, err = sc.getUrlOverrides(workCtx, monitorIds, options.BaseUrl)
+		if err != nil {
+			return nil, nil, err
+		}
 	}
 
-	return ingestResponseBody, nil
+	jsonData, err := generateExecutionByIdsEvent(monitorIds, locationIds, options, urlOverrides)
+	return jsonData, urlOverrides, err
 }
 
-func (sc *SyntheticConnector) getBatchExecutionData(workCtx context.Context) (BatchResponseBody, error) {
-	path := getSyntheticBatchPath(sc.executionData.BatchId)
-	resp, err := sc.dtClient.Get(workCtx, path)
+// resolveMonitorNames returns the ids of the monitors matching the selected names as well as the names not matching any monitor.
+func (sc *SyntheticConnector) resolveMonitorNames(workCtx context.Context, selection MonitorSelection) ([]string, []string, error) {
+	if len(selection.MonitorNames) == 0 {
+		return []string{}, []string{}, nil
+	}
+
+	monitors, err := dynatrace.NewSyntheticMonitorsClient(sc.dtClient).GetAll(workCtx)
 	if err != nil {
-		return BatchResponseBody{}, err
+		return nil, nil, fmt.Errorf("could not retrieve synthetic monitors: %w", err)
+	}
+
+	return selection.matchMonitorNames(monitors)
+}
+
+// isLocationId checks whether the location is specified by a public (GEOLOCATION-...) or private (SYNTHETIC_LOCATION-...) location id rather than by name.
+func isLocationId(location string) bool {
+	return strings.HasPrefix(location, publicLocationIdPrefix) || strings.HasPrefix(location, privateLocationIdPrefix)
+}
+
+// resolveLocationIds resolves locations specified by name to location ids. Locations specified by id are returned as is.
+func (sc *SyntheticConnector) resolveLocationIds(workCtx context.Context, locations []string) ([]string, error) {
+	if len(locations) == 0 {
+		return nil, nil
 	}
 
-	log.Debug(string(resp))
+	isNameDefined := false
+	for _, location := range locations {
+		if !isLocationId(location) {
+			isNameDefined = true
+			break
+		}
+	}
+
+	if !isNameDefined {
+		return locations, nil
+	}
 
-	batchResponseBody := BatchResponseBody{}
-	err = json.Unmarshal(resp, &batchResponseBody)
+	availableLocations, err := dynatrace.NewSyntheticLocationsClient(sc.dtClient).GetAll(workCtx)
 	if err != nil {
-		log.Error(err.Error())
-		return BatchResponseBody{}, err
+		return nil, fmt.Errorf("could not retrieve synthetic locations: %w", err)
 	}
 
-	return batchResponseBody, nil
+	return matchLocationIds(locations, availableLocations)
+}
+
+// matchLocationIds matches locations specified by name to the ids of available locations or returns an error listing all unknown locations.
+func matchLocationIds(locations []string, availableLocations []dynatrace.SyntheticLocation) ([]string, error) {
+	locationIdsByName := make(map[string]string, len(availableLocations))
+	for _, availableLocation := range availableLocations {
+		locationIdsByName[availableLocation.Name] = availableLocation.EntityID
+	}
+
+	locationIds := make([]string, 0, len(locations))
+	unknownLocations := []string{}
+	for _, location := range locations {
+		if isLocationId(location) {
+			locationIds = append(locationIds, location)
+			continue
+		}
+
+		locationId, found := locationIdsByName[location]
+		if !found {
+			unknownLocations = append(unknownLocations, location)
+			continue
+		}
+
+		locationIds = append(locationIds, locationId)
+	}
+
+	if len(unknownLocations) > 0 {
+		return nil, fmt.Errorf("unknown synthetic locations: %s", strings.Join(unknownLocations, ", "))
+	}
+
+	return locationIds, nil
 }
 
 // Calculates synthetic execution success rate
@@ -242,48 +552,90 @@ func calculateSuccessRate(batchResponseBody BatchResponseBody) (float64, error)
 	return successRateRounded, nil
 }
 
-// Waits for the last triggered batch to return an SUCCES or FAILED status.
-//
-// Attention: Does not wait for data retrieval
-//
-func (sc *SyntheticConnector) WaitForBatchExecution(workCtx context.Context) (BatchResponseBody, float64, error) {
-	pollingStartTime := time.Now().UTC()
-	pollingTimeout, err := time.ParseDuration("300s")
-	if err != nil {
-		return BatchResponseBody{}, 0, err
-	}
-
-	requestCounter := 1
-	pollingInterval := 10 * time.Second
+// syntheticDataQuery is a metrics query for the availability of a set of synthetic monitors of the same type.
+type syntheticDataQuery struct {
+	query        metrics.Query
+	dimensionKey string
+	monitorIds   []string
+}
 
-	for {
-		currentPollingTime := time.Now().UTC()
-		if currentPollingTime.Sub(pollingStartTime).Seconds() > pollingTimeout.Seconds() {
-			return BatchResponseBody{}, 0, fmt.Errorf("could not retrieve data within %f seconds", pollingTimeout.Seconds())
+// createSyntheticDataQueries creates one availability query per monitor type (HTTP or browser) of the specified monitors.
+func createSyntheticDataQueries(monitorIds []string) ([]syntheticDataQuery, error) {
+	httpMonitorIds := []string{}
+	browserMonitorIds := []string{}
+
+	for _, monitorId := range monitorIds {
+		switch {
+		case strings.HasPrefix(monitorId, httpMonitorIdPrefix):
+			httpMonitorIds = append(httpMonitorIds, monitorId)
+		case strings.HasPrefix(monitorId, browserMonitorIdPrefix):
+			browserMonitorIds = append(browserMonitorIds, monitorId)
+		default:
+			return nil, fmt.Errorf("unsupported synthetic monitor id: %s", monitorId)
 		}
+	}
 
-		log.Debug("Requesting data (", requestCounter, ")")
+	queries := []syntheticDataQuery{}
 
-		batchResponseBody, err := sc.getBatchExecutionData(workCtx)
+	if len(httpMonitorIds) > 0 {
+		query, err := createSyntheticDataQuery(httpAvailabilityMetricKey, httpMonitorDimensionKey, "HTTP_CHECK", httpMonitorIds)
 		if err != nil {
-			return BatchResponseBody{}, 0, err
+			return nil, err
 		}
+		queries = append(queries, *query)
+	}
 
-		// batchStatus = RUNNING || SUCCESS || FAILED
-		if batchResponseBody.BatchStatus == "SUCCESS" || batchResponseBody.BatchStatus == "FAILED" {
-			successRate, _ := calculateSuccessRate(batchResponseBody)
-			return batchResponseBody, successRate, nil
+	if len(browserMonitorIds) > 0 {
+		query, err := createSyntheticDataQuery(browserAvailabilityMetricKey, browserMonitorDimensionKey, "SYNTHETIC_TEST", browserMonitorIds)
+		if err != nil {
+			return nil, err
 		}
+		queries = append(queries, *query)
+	}
 
-		log.Debug("Waiting ", pollingInterval.Seconds(), " seconds...")
-		time.Sleep(pollingInterval)
+	return queries, nil
+}
 
-		requestCounter++
+func createSyntheticDataQuery(metricKey string, dimensionKey string, entityType string, monitorIds []string) (*syntheticDataQuery, error) {
+	quotedMonitorIds := make([]string, len(monitorIds))
+	for i, monitorId := range monitorIds {
+		quotedMonitorIds[i] = fmt.Sprintf("\"%s\"", monitorId)
 	}
+
+	query, err := metrics.NewQuery(
+		fmt.Sprintf("%s:splitBy(\"%s\")", metricKey, dimensionKey),
+		fmt.Sprintf("type(%s),entityId(%s)", entityType, strings.Join(quotedMonitorIds, ",")))
+	if err != nil {
+		return nil, err
+	}
+
+	return &syntheticDataQuery{
+		query:        *query,
+		dimensionKey: dimensionKey,
+		monitorIds:   monitorIds,
+	}, nil
 }
 
-// TBD
-// func (sc *SyntheticConnector) WaitForBatchData(workCtx context.Context) error {}
+// hasDataForAllMonitors checks whether the metrics query result contains at least one data point for each of the queried monitors.
+func hasDataForAllMonitors(dataQuery syntheticDataQuery, result *dynatrace.MetricsQueryResult) bool {
+	monitorsWithData := map[string]bool{}
+
+	for _, resultValues := range result.Result {
+		for _, data := range resultValues.Data {
+			if len(data.Values) > 0 {
+				monitorsWithData[data.DimensionMap[dataQuery.dimensionKey]] = true
+			}
+		}
+	}
+
+	for _, monitorId := range dataQuery.monitorIds {
+		if !monitorsWithData[monitorId] {
+			return false
+		}
+	}
+
+	return true
+}
 
 func NewSyntheticConnector(dtClient dynatrace.ClientInterface) *SyntheticConnector {
 	return &SyntheticConnector{
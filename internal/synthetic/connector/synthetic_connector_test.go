@@ -2,18 +2,20 @@ package connector
 
 import (
 	"context"
-	"os"
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 
-	cloudevents "github.com/cloudevents/sdk-go/v2"
-	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 	"github.com/keptn-contrib/dynatrace-service/internal/env"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
 	log "github.com/sirupsen/logrus"
 	"github.com/stretchr/testify/assert"
 )
 
 const mockSyntheticTestId = "TEST_SYNTHETIC_TEST_ID"
+const mockLocationId = "TEST_LOCATION_ID"
 const mockProjectName = "TEST_PROJECT_NAME"
 const mockServiceName = "TEST_SERVICE_NAME"
 const mockStageName = "TEST_STAGE_NAME"
@@ -25,15 +27,31 @@ func init() {
 }
 
 func TestIngestSyntheticSuccessMetric(t *testing.T) {
-	mockDynatraceCredentials, err := credentials.NewDynatraceCredentials(os.Getenv("DT_TENANT"), os.Getenv("DT_API_TOKEN"))
-	assert.Nil(t, err)
-	mockDtClient := dynatrace.NewClient(mockDynatraceCredentials)
-	mockCtx := cloudevents.WithEncodingStructured(context.Background())
+	ingestedLines := []string{}
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v2/metrics/ingest", r.URL.Path)
 
-	mockPostData := []byte(generateMetricsIngestLine(mockSyntheticTestId, mockProjectName, mockServiceName, mockStageName, mockBatchId, mockGauge))
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		ingestedLines = append(ingestedLines, strings.Split(string(body), "\n")...)
 
-	_, err = mockDtClient.PostTextPlain(mockCtx, metricsIngestPath, mockPostData)
-	assert.Nil(t, err)
+		w.WriteHeader(http.StatusAccepted)
+		_, _ = w.Write([]byte(`{"linesOk":1,"linesInvalid":0}`))
+	})
+	sc, teardown := createSyntheticConnector(t, handler)
+	defer teardown()
+
+	mockLine, err := generateMetricsIngestLine(executionSuccessMetricKey, mockSyntheticTestId, mockLocationId, mockProjectName, mockServiceName, mockStageName, mockBatchId, mockGauge)
+	assert.NoError(t, err)
+
+	response, err := dynatrace.NewMetricsIngestClient(sc.dtClient).Ingest(context.TODO(), []dynatrace.MetricLine{mockLine})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, response.LinesOk)
+	if assert.Len(t, ingestedLines, 1) {
+		assert.True(t, strings.HasPrefix(ingestedLines[0], executionSuccessMetricKey+","))
+		assert.Contains(t, ingestedLines[0], "ca.synthetic.batch_id="+mockBatchId)
+		assert.True(t, strings.HasSuffix(ingestedLines[0], " gauge,42"))
+	}
 }
 
 func TestCalculateSuccessRate(t *testing.T) {
@@ -55,3 +73,218 @@ func TestCalculateSuccessRate(t *testing.T) {
 	successRate, _ = calculateSuccessRate(mockBatchResponseBody)
 	assert.Equal(t, float64(0), successRate)
 }
+
+func TestCreateSyntheticDataQueries(t *testing.T) {
+	dataQueries, err := createSyntheticDataQueries([]string{"HTTP_CHECK-1", "SYNTHETIC_TEST-2", "HTTP_CHECK-3"})
+	assert.NoError(t, err)
+	if assert.Equal(t, 2, len(dataQueries)) {
+		assert.Equal(t, "builtin:synthetic.http.availability.location.total:splitBy(\"dt.entity.http_check\")", dataQueries[0].query.GetMetricSelector())
+		assert.Equal(t, "type(HTTP_CHECK),entityId(\"HTTP_CHECK-1\",\"HTTP_CHECK-3\")", dataQueries[0].query.GetEntitySelector())
+		assert.Equal(t, []string{"HTTP_CHECK-1", "HTTP_CHECK-3"}, dataQueries[0].monitorIds)
+
+		assert.Equal(t, "builtin:synthetic.browser.availability.location.total:splitBy(\"dt.entity.synthetic_test\")", dataQueries[1].query.GetMetricSelector())
+		assert.Equal(t, "type(SYNTHETIC_TEST),entityId(\"SYNTHETIC_TEST-2\")", dataQueries[1].query.GetEntitySelector())
+		assert.Equal(t, []string{"SYNTHETIC_TEST-2"}, dataQueries[1].monitorIds)
+	}
+
+	dataQueries, err = createSyntheticDataQueries([]string{"SERVICE-1"})
+	assert.Error(t, err)
+	assert.Nil(t, dataQueries)
+}
+
+func TestHasDataForAllMonitors(t *testing.T) {
+	dataQueries, err := createSyntheticDataQueries([]string{"HTTP_CHECK-1", "HTTP_CHECK-2"})
+	assert.NoError(t, err)
+
+	partialResult := &dynatrace.MetricsQueryResult{
+		Result: []dynatrace.MetricQueryResultValues{
+			{
+				MetricID: httpAvailabilityMetricKey,
+				Data: []dynatrace.MetricQueryResultNumbers{
+					{DimensionMap: map[string]string{httpMonitorDimensionKey: "HTTP_CHECK-1"}, Values: []float64{100}},
+					{DimensionMap: map[string]string{httpMonitorDimensionKey: "HTTP_CHECK-2"}, Values: []float64{}},
+				},
+			},
+		},
+	}
+	assert.False(t, hasDataForAllMonitors(dataQueries[0], partialResult))
+
+	completeResult := &dynatrace.MetricsQueryResult{
+		Result: []dynatrace.MetricQueryResultValues{
+			{
+				MetricID: httpAvailabilityMetricKey,
+				Data: []dynatrace.MetricQueryResultNumbers{
+					{DimensionMap: map[string]string{httpMonitorDimensionKey: "HTTP_CHECK-1"}, Values: []float64{100}},
+					{DimensionMap: map[string]string{httpMonitorDimensionKey: "HTTP_CHECK-2"}, Values: []float64{0}},
+				},
+			},
+		},
+	}
+	assert.True(t, hasDataForAllMonitors(dataQueries[0], completeResult))
+}
+
+func TestGenerateExecutionEvents(t *testing.T) {
+	jsonData, err := generateExecutionByIdsEvent([]string{"HTTP_CHECK-1"}, nil, ExecutionOptions{}, nil)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"monitors":[{"monitorId":"HTTP_CHECK-1","locations":[]}]}`, string(jsonData))
+
+	jsonData, err = generateExecutionByIdsEvent([]string{"HTTP_CHECK-1", "SYNTHETIC_TEST-2"}, []string{"GEOLOCATION-1"}, ExecutionOptions{}, nil)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"monitors":[{"monitorId":"HTTP_CHECK-1","locations":["GEOLOCATION-1"]},{"monitorId":"SYNTHETIC_TEST-2","locations":["GEOLOCATION-1"]}]}`, string(jsonData))
+
+	jsonData, err = generateExecutionByTagEvent("my-tag", nil, ExecutionOptions{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"group":{"tags":["my-tag"]}}`, string(jsonData))
+
+	jsonData, err = generateExecutionByTagEvent("my-tag", []string{"SYNTHETIC_LOCATION-1"}, ExecutionOptions{})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"group":{"tags":["my-tag"],"locations":["SYNTHETIC_LOCATION-1"]}}`, string(jsonData))
+}
+
+func TestGenerateExecutionEvents_WithOptions(t *testing.T) {
+	enabled := true
+	disabled := false
+	options := ExecutionOptions{
+		ProcessingMode:           ProcessingModeDisableProblemDetection,
+		FailOnPerformanceIssue:   &enabled,
+		FailOnSslWarning:         &disabled,
+		StopOnProblem:            &enabled,
+		TakeScreenshotsOnSuccess: &enabled,
+		CustomizedScript: &CustomizedScript{
+			RequestHeaders: []RequestHeader{{Name: "X-Keptn-Context", Value: "abc"}},
+		},
+	}
+
+	jsonData, err := generateExecutionByIdsEvent([]string{"HTTP_CHECK-1"}, nil, options, nil)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"monitors":[{"monitorId":"HTTP_CHECK-1","locations":[],"customizedScript":{"requestHeaders":[{"name":"X-Keptn-Context","value":"abc"}]}}],
+		"processingMode":"DISABLE_PROBLEM_DETECTION",
+		"failOnPerformanceIssue":true,
+		"failOnSslWarning":false,
+		"stopOnProblem":true,
+		"takeScreenshotsOnSuccess":true
+	}`, string(jsonData))
+
+	jsonData, err = generateExecutionByTagEvent("my-tag", nil, ExecutionOptions{ProcessingMode: ProcessingModeStandard})
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"group":{"tags":["my-tag"]},"processingMode":"STANDARD"}`, string(jsonData))
+}
+
+func TestGenerateExecutionByMonitorLocationsEvent(t *testing.T) {
+	monitorLocations := []MonitorLocation{
+		{MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1"},
+		{MonitorId: "SYNTHETIC_TEST-2"},
+		{MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-2"},
+		{MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1"},
+	}
+
+	jsonData, err := generateExecutionByMonitorLocationsEvent(monitorLocations, nil, ExecutionOptions{}, nil)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"monitors":[{"monitorId":"HTTP_CHECK-1","locations":["GEOLOCATION-1","GEOLOCATION-2"]},{"monitorId":"SYNTHETIC_TEST-2","locations":[]}]}`, string(jsonData))
+
+	jsonData, err = generateExecutionByMonitorLocationsEvent(monitorLocations, []string{"SYNTHETIC_LOCATION-3"}, ExecutionOptions{}, nil)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"monitors":[{"monitorId":"HTTP_CHECK-1","locations":["GEOLOCATION-1","GEOLOCATION-2"]},{"monitorId":"SYNTHETIC_TEST-2","locations":["SYNTHETIC_LOCATION-3"]}]}`, string(jsonData))
+}
+
+func TestMatchLocationIds(t *testing.T) {
+	availableLocations := []dynatrace.SyntheticLocation{
+		{EntityID: "GEOLOCATION-1", Name: "N. Virginia (Amazon US East)", Type: "PUBLIC"},
+		{EntityID: "SYNTHETIC_LOCATION-2", Name: "Internal ActiveGate", Type: "PRIVATE"},
+	}
+
+	locationIds, err := matchLocationIds([]string{"Internal ActiveGate", "GEOLOCATION-3"}, availableLocations)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"SYNTHETIC_LOCATION-2", "GEOLOCATION-3"}, locationIds)
+
+	locationIds, err = matchLocationIds([]string{"Internal ActiveGate", "Mars", "Moon"}, availableLocations)
+	assert.EqualError(t, err, "unknown synthetic locations: Mars, Moon")
+	assert.Nil(t, locationIds)
+}
+
+func TestSyntheticConnector_generateExecutionEvent(t *testing.T) {
+	handler := test.NewPayloadBasedURLHandler(t)
+	handler.AddExact("/api/v1/synthetic/monitors?tag=app%3Aeasytravel&tag=smoke", []byte(`{"monitors":[{"entityId":"HTTP_CHECK-1"},{"entityId":"HTTP_CHECK-2"}]}`))
+	handler.AddExact("/api/v1/synthetic/monitors?tag=critical", []byte(`{"monitors":[{"entityId":"HTTP_CHECK-2"},{"entityId":"SYNTHETIC_TEST-3"}]}`))
+	handler.AddExact("/api/v1/synthetic/monitors?tag=unknown", []byte(`{"monitors":[]}`))
+	sc, teardown := createSyntheticConnector(t, handler)
+	defer teardown()
+
+	tests := []struct {
+		name      string
+		selection MonitorSelection
+		want      string
+		wantErr   bool
+	}{
+		{
+			name:      "single tag is triggered as group",
+			selection: MonitorSelection{MonitorTags: []string{"smoke"}},
+			want:      `{"group":{"tags":["smoke"]}}`,
+		},
+		{
+			name:      "tag expressions are resolved to monitor ids",
+			selection: MonitorSelection{MonitorTags: []string{"app:easytravel AND smoke OR critical"}},
+			want:      `{"monitors":[{"monitorId":"HTTP_CHECK-1","locations":[]},{"monitorId":"HTTP_CHECK-2","locations":[]},{"monitorId":"SYNTHETIC_TEST-3","locations":[]}]}`,
+		},
+		{
+			name:      "monitor ids and tags are combined",
+			selection: MonitorSelection{MonitorIds: []string{"HTTP_CHECK-4", "HTTP_CHECK-2"}, MonitorTags: []string{"critical"}},
+			want:      `{"monitors":[{"monitorId":"HTTP_CHECK-4","locations":[]},{"monitorId":"HTTP_CHECK-2","locations":[]},{"monitorId":"SYNTHETIC_TEST-3","locations":[]}]}`,
+		},
+		{
+			name:      "no monitors found",
+			selection: MonitorSelection{MonitorIds: []string{}, MonitorTags: []string{"unknown", "unknown"}},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jsonData, _, err := sc.generateExecutionEvent(context.TODO(), tt.selection, nil, ExecutionOptions{})
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(jsonData))
+		})
+	}
+}
+
+func TestSyntheticBatch_Report(t *testing.T) {
+	handler := test.NewFileBasedURLHandler(t)
+	handler.AddExact("/api/v2/synthetic/executions/1234567890/fullReport", "./testdata/execution_full_report_failed.json")
+	sc, teardown := createSyntheticConnector(t, handler)
+	defer teardown()
+
+	batch := sc.RestoreBatch(TriggeredBatch{ExecutionData: ExecutionData{ExecutionIds: []string{"1234567890"}}})
+
+	executionReports, err := batch.Report(context.TODO())
+	assert.NoError(t, err)
+	assert.Equal(t, []ExecutionReport{
+		{
+			ExecutionId:        "1234567890",
+			MonitorId:          "HTTP_CHECK-0000000000000001",
+			LocationId:         "GEOLOCATION-9999453BE4BDB3CD",
+			ExecutionStage:     "DATA_RETRIEVED",
+			ExecutionTimestamp: 1650000005000,
+			SimpleResults: ExecutionSimpleResults{
+				StartTimestamp:       1650000005000,
+				Status:               "FAILED",
+				ErrorCode:            "CONSTRAINT_VIOLATED",
+				FailureMessage:       "Response status code 503 violates constraint",
+				FailedStepName:       "GET checkout",
+				FailedStepSequenceId: 2,
+				ResponseStatusCode:   503,
+				Duration:             1234,
+			},
+			FullResults: ExecutionFullResults{
+				ExecutionSteps: []ExecutionStepResult{
+					{RequestId: "1", RequestName: "GET home", StartTimestamp: 1650000005000, Status: "SUCCESS", ResponseStatusCode: 200, ResponseTime: 234},
+					{RequestId: "2", RequestName: "GET checkout", StartTimestamp: 1650000005234, Status: "FAILED", ResponseStatusCode: 503, ResponseTime: 1000, ErrorCode: "CONSTRAINT_VIOLATED", FailureMessage: "Response status code 503 violates constraint"},
+				},
+			},
+		},
+	}, executionReports)
+}
@@ -0,0 +1,175 @@
+package connector
+
+import (
+	"context"
+	"math"
+	"strings"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+)
+
+// SuccessfulStepStatus is the status of successful steps of Dynatrace Synthetic executions
+const SuccessfulStepStatus = "SUCCESS"
+
+// monitorLocationMetrics are the metrics of all executions of a monitor at a location within a batch
+type monitorLocationMetrics struct {
+	monitorId   string
+	locationId  string
+	successRate float64
+
+	// failedSteps and duration are only available if execution reports were retrieved
+	hasReports  bool
+	failedSteps int
+	duration    float64
+}
+
+// calculateMonitorLocationMetrics calculates the success rate, the number of failed steps and the average duration of the executions of each monitor and location pair.
+// The success rate is based on the failed executions, while failed steps and duration are based on the execution reports.
+func calculateMonitorLocationMetrics(executionData ExecutionData) []monitorLocationMetrics {
+	failedExecutionIds := make(map[string]bool, len(executionData.FailedExecutions))
+	for _, failedExecution := range executionData.FailedExecutions {
+		failedExecutionIds[failedExecution.ExecutionId] = true
+	}
+
+	reportsByExecutionId := make(map[string]ExecutionReport, len(executionData.Executions))
+	for _, report := range executionData.Executions {
+		reportsByExecutionId[report.ExecutionId] = report
+	}
+
+	type accumulator struct {
+		executions           int
+		successfulExecutions int
+		reports              int
+		failedSteps          int
+		totalDuration        int64
+	}
+
+	order := []MonitorLocation{}
+	accumulators := make(map[MonitorLocation]*accumulator)
+	for _, triggeredExecution := range executionData.TriggeredExecutions {
+		monitorLocation := MonitorLocation{MonitorId: triggeredExecution.MonitorId, LocationId: triggeredExecution.LocationId}
+		acc, found := accumulators[monitorLocation]
+		if !found {
+			acc = &accumulator{}
+			accumulators[monitorLocation] = acc
+			order = append(order, monitorLocation)
+		}
+
+		acc.executions++
+		if !failedExecutionIds[triggeredExecution.ExecutionId] {
+			acc.successfulExecutions++
+		}
+
+		report, found := reportsByExecutionId[triggeredExecution.ExecutionId]
+		if !found {
+			continue
+		}
+
+		acc.reports++
+		acc.failedSteps += countFailedSteps(report)
+		acc.totalDuration += report.SimpleResults.Duration
+	}
+
+	metrics := make([]monitorLocationMetrics, 0, len(order))
+	for _, monitorLocation := range order {
+		acc := accumulators[monitorLocation]
+		m := monitorLocationMetrics{
+			monitorId:   monitorLocation.MonitorId,
+			locationId:  monitorLocation.LocationId,
+			successRate: math.Round(float64(acc.successfulExecutions)/float64(acc.executions)*10000) / 100,
+			hasReports:  acc.reports > 0,
+			failedSteps: acc.failedSteps,
+		}
+
+		if acc.reports > 0 {
+			m.duration = float64(acc.totalDuration) / float64(acc.reports)
+		}
+
+		metrics = append(metrics, m)
+	}
+
+	return metrics
+}
+
+func countFailedSteps(report ExecutionReport) int {
+	failedSteps := 0
+	for _, step := range report.FullResults.ExecutionSteps {
+		if step.Status != SuccessfulStepStatus {
+			failedSteps++
+		}
+	}
+
+	return failedSteps
+}
+
+// getMonitorDimensionKey returns the dimension key of the entity type of the monitor
+func getMonitorDimensionKey(monitorId string) string {
+	if strings.HasPrefix(monitorId, httpMonitorIdPrefix) {
+		return httpMonitorDimensionKey
+	}
+
+	return browserMonitorDimensionKey
+}
+
+func generateMetricsIngestLine(metricKey string, monitorId string, locationId string, projectName string, serviceName string, stageName string, batchId string, gauge float64) (dynatrace.MetricLine, error) {
+	return dynatrace.NewMetricLineBuilder(metricKey).
+		Dimension(getMonitorDimensionKey(monitorId), monitorId).
+		Dimension(locationDimensionKey, locationId).
+		Dimension("ca.project.name", projectName).
+		Dimension("ca.service.name", serviceName).
+		Dimension("ca.stage.name", stageName).
+		Dimension("ca.synthetic.batch_id", batchId).
+		Gauge(gauge).
+		Build()
+}
+
+// generateMetricsIngestLines generates the success rate, failed steps and duration lines for each monitor and location pair of the batch
+func generateMetricsIngestLines(executionData ExecutionData, projectName string, serviceName string, stageName string) ([]dynatrace.MetricLine, error) {
+	lines := []dynatrace.MetricLine{}
+	addLine := func(metricKey string, m monitorLocationMetrics, gauge float64) error {
+		line, err := generateMetricsIngestLine(metricKey, m.monitorId, m.locationId, projectName, serviceName, stageName, executionData.BatchId, gauge)
+		if err != nil {
+			return err
+		}
+
+		lines = append(lines, line)
+		return nil
+	}
+
+	for _, m := range calculateMonitorLocationMetrics(executionData) {
+		err := addLine(executionSuccessMetricKey, m, m.successRate)
+		if err != nil {
+			return nil, err
+		}
+
+		if !m.hasReports {
+			continue
+		}
+
+		err = addLine(executionFailedStepsMetricKey, m, float64(m.failedSteps))
+		if err != nil {
+			return nil, err
+		}
+
+		err = addLine(executionDurationMetricKey, m, m.duration)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return lines, nil
+}
+
+// IngestSyntheticMetrics ingests the success rate, failed steps and duration of each monitor and location pair of the batch as custom metrics.
+func (sc *SyntheticConnector) IngestSyntheticMetrics(workCtx context.Context, executionData ExecutionData, projectName string, serviceName string, stageName string) (dynatrace.MetricsIngestResponse, error) {
+	lines, err := generateMetricsIngestLines(executionData, projectName, serviceName, stageName)
+	if err != nil {
+		return dynatrace.MetricsIngestResponse{}, err
+	}
+
+	if len(lines) == 0 {
+		return dynatrace.MetricsIngestResponse{}, nil
+	}
+
+	return dynatrace.NewMetricsIngestClient(sc.dtClient).Ingest(workCtx, lines)
+}
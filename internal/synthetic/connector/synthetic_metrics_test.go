@@ -0,0 +1,66 @@
+package connector
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalculateMonitorLocationMetrics(t *testing.T) {
+	executionData := ExecutionData{
+		BatchId: "batch-1",
+		TriggeredExecutions: []TriggeredExecution{
+			{ExecutionId: "1", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1"},
+			{ExecutionId: "2", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1"},
+			{ExecutionId: "3", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-2"},
+			{ExecutionId: "4", MonitorId: "SYNTHETIC_TEST-2", LocationId: "GEOLOCATION-1"},
+		},
+		FailedExecutions: []ExecutionNotSuccessful{
+			{ExecutionId: "2", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1"},
+			{ExecutionId: "4", MonitorId: "SYNTHETIC_TEST-2", LocationId: "GEOLOCATION-1"},
+		},
+		Executions: []ExecutionReport{
+			{ExecutionId: "1", SimpleResults: ExecutionSimpleResults{Duration: 100}, FullResults: ExecutionFullResults{ExecutionSteps: []ExecutionStepResult{{Status: "SUCCESS"}}}},
+			{ExecutionId: "2", SimpleResults: ExecutionSimpleResults{Duration: 300}, FullResults: ExecutionFullResults{ExecutionSteps: []ExecutionStepResult{{Status: "SUCCESS"}, {Status: "FAILED"}}}},
+			{ExecutionId: "4", SimpleResults: ExecutionSimpleResults{Duration: 2000}, FullResults: ExecutionFullResults{ExecutionSteps: []ExecutionStepResult{{Status: "FAILED"}, {Status: "SKIPPED"}}}},
+		},
+	}
+
+	assert.Equal(t, []monitorLocationMetrics{
+		{monitorId: "HTTP_CHECK-1", locationId: "GEOLOCATION-1", successRate: 50, hasReports: true, failedSteps: 1, duration: 200},
+		{monitorId: "HTTP_CHECK-1", locationId: "GEOLOCATION-2", successRate: 100},
+		{monitorId: "SYNTHETIC_TEST-2", locationId: "GEOLOCATION-1", successRate: 0, hasReports: true, failedSteps: 2, duration: 2000},
+	}, calculateMonitorLocationMetrics(executionData))
+}
+
+func TestGenerateMetricsIngestLines(t *testing.T) {
+	executionData := ExecutionData{
+		BatchId: "batch-1",
+		TriggeredExecutions: []TriggeredExecution{
+			{ExecutionId: "1", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1"},
+			{ExecutionId: "2", MonitorId: "SYNTHETIC_TEST-2", LocationId: "SYNTHETIC_LOCATION-1"},
+		},
+		Executions: []ExecutionReport{
+			{ExecutionId: "2", SimpleResults: ExecutionSimpleResults{Duration: 1500}},
+		},
+	}
+
+	lines, err := generateMetricsIngestLines(executionData, "my project", "service", "stage")
+	assert.NoError(t, err)
+
+	wantLines := []string{
+		"ca.synthetic.execution_success_rate,dt.entity.http_check=HTTP_CHECK-1,dt.entity.synthetic_location=GEOLOCATION-1,ca.project.name=\"my project\",ca.service.name=service,ca.stage.name=stage,ca.synthetic.batch_id=batch-1 gauge,100",
+		"ca.synthetic.execution_success_rate,dt.entity.synthetic_test=SYNTHETIC_TEST-2,dt.entity.synthetic_location=SYNTHETIC_LOCATION-1,ca.project.name=\"my project\",ca.service.name=service,ca.stage.name=stage,ca.synthetic.batch_id=batch-1 gauge,100",
+		"ca.synthetic.execution_failed_steps,dt.entity.synthetic_test=SYNTHETIC_TEST-2,dt.entity.synthetic_location=SYNTHETIC_LOCATION-1,ca.project.name=\"my project\",ca.service.name=service,ca.stage.name=stage,ca.synthetic.batch_id=batch-1 gauge,0",
+		"ca.synthetic.execution_duration,dt.entity.synthetic_test=SYNTHETIC_TEST-2,dt.entity.synthetic_location=SYNTHETIC_LOCATION-1,ca.project.name=\"my project\",ca.service.name=service,ca.stage.name=stage,ca.synthetic.batch_id=batch-1 gauge,1500",
+	}
+	if assert.Equal(t, len(wantLines), len(lines)) {
+		for i, wantLine := range wantLines {
+			assert.Equal(t, wantLine, lines[i].String())
+		}
+	}
+
+	lines, err = generateMetricsIngestLines(ExecutionData{BatchId: "batch-1"}, "project", "service", "stage")
+	assert.NoError(t, err)
+	assert.Empty(t, lines)
+}
@@ -0,0 +1,157 @@
+package connector
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+)
+
+// UrlOverrideTypeRequest identifies an overridden request of an HTTP monitor
+const UrlOverrideTypeRequest = "REQUEST"
+
+// UrlOverrideTypeEvent identifies an overridden navigate event of a browser monitor
+const UrlOverrideTypeEvent = "EVENT"
+
+// UrlOverride is the URL of a request or event of a monitor rewritten to the base URL
+type UrlOverride struct {
+	MonitorId   string `json:"monitorId"`
+	Type        string `json:"type"`
+	Id          int    `json:"id"`
+	OriginalUrl string `json:"originalUrl"`
+	Url         string `json:"url"`
+}
+
+// ScriptUrlCustomization overrides the URL of the request or event with the specified id, i.e. its 1-based position in the script
+type ScriptUrlCustomization struct {
+	Id  int    `json:"id"`
+	Url string `json:"url"`
+}
+
+// parseBaseUrl parses the base URL, which must be an absolute http or https URL
+func parseBaseUrl(baseUrl string) (*url.URL, error) {
+	parsedBaseUrl, err := url.Parse(baseUrl)
+	if err != nil {
+		return nil, fmt.Errorf("invalid baseUrl '%s': %w", baseUrl, err)
+	}
+
+	if (parsedBaseUrl.Scheme != "http" && parsedBaseUrl.Scheme != "https") || parsedBaseUrl.Host == "" {
+		return nil, fmt.Errorf("invalid baseUrl '%s': must be an absolute http or https URL", baseUrl)
+	}
+
+	return parsedBaseUrl, nil
+}
+
+// rewriteUrl replaces scheme, host and port of the URL with those of the base URL and prefixes its path with the path of the base URL
+func rewriteUrl(originalUrl string, baseUrl *url.URL) (string, error) {
+	parsedUrl, err := url.Parse(originalUrl)
+	if err != nil {
+		return "", err
+	}
+
+	parsedUrl.Scheme = baseUrl.Scheme
+	parsedUrl.Host = baseUrl.Host
+	if basePath := strings.TrimSuffix(baseUrl.Path, "/"); basePath != "" {
+		parsedUrl.Path = basePath + parsedUrl.Path
+		parsedUrl.RawPath = ""
+	}
+
+	return parsedUrl.String(), nil
+}
+
+// createUrlOverrides rewrites the URLs of all requests of HTTP monitors and all navigate events of browser monitors to the base URL.
+func createUrlOverrides(monitor dynatrace.SyntheticMonitor, baseUrl *url.URL) ([]UrlOverride, error) {
+	urlOverrides := []UrlOverride{}
+	addUrlOverride := func(overrideType string, id int, originalUrl string) error {
+		rewrittenUrl, err := rewriteUrl(originalUrl, baseUrl)
+		if err != nil {
+			return fmt.Errorf("could not rewrite URL '%s' of synthetic monitor %s: %w", originalUrl, monitor.EntityID, err)
+		}
+
+		urlOverrides = append(urlOverrides, UrlOverride{
+			MonitorId:   monitor.EntityID,
+			Type:        overrideType,
+			Id:          id,
+			OriginalUrl: originalUrl,
+			Url:         rewrittenUrl,
+		})
+		return nil
+	}
+
+	for i, request := range monitor.Script.Requests {
+		err := addUrlOverride(UrlOverrideTypeRequest, i+1, request.URL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i, event := range monitor.Script.Events {
+		if event.URL == "" {
+			continue
+		}
+
+		err := addUrlOverride(UrlOverrideTypeEvent, i+1, event.URL)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return urlOverrides, nil
+}
+
+// getUrlOverrides retrieves the scripts of the monitors and rewrites their URLs to the base URL
+func (sc *SyntheticConnector) getUrlOverrides(workCtx context.Context, monitorIds []string, baseUrl string) ([]UrlOverride, error) {
+	parsedBaseUrl, err := parseBaseUrl(baseUrl)
+	if err != nil {
+		return nil, err
+	}
+
+	monitorsClient := dynatrace.NewSyntheticMonitorsClient(sc.dtClient)
+
+	urlOverrides := []UrlOverride{}
+	for _, monitorId := range monitorIds {
+		monitor, err := monitorsClient.GetByID(workCtx, monitorId)
+		if err != nil {
+			return nil, fmt.Errorf("could not retrieve synthetic monitor %s: %w", monitorId, err)
+		}
+
+		monitorUrlOverrides, err := createUrlOverrides(*monitor, parsedBaseUrl)
+		if err != nil {
+			return nil, err
+		}
+
+		urlOverrides = append(urlOverrides, monitorUrlOverrides...)
+	}
+
+	return urlOverrides, nil
+}
+
+// getCustomizedScript combines the customized script of the options with the URL overrides of the monitor
+func getCustomizedScript(monitorId string, options ExecutionOptions, urlOverrides []UrlOverride) *CustomizedScript {
+	customizedScript := CustomizedScript{}
+	if options.CustomizedScript != nil {
+		customizedScript.RequestHeaders = options.CustomizedScript.RequestHeaders
+	}
+
+	for _, urlOverride := range urlOverrides {
+		if urlOverride.MonitorId != monitorId {
+			continue
+		}
+
+		customization := ScriptUrlCustomization{Id: urlOverride.Id, Url: urlOverride.Url}
+		switch urlOverride.Type {
+		case UrlOverrideTypeRequest:
+			customizedScript.Requests = append(customizedScript.Requests, customization)
+		case UrlOverrideTypeEvent:
+			customizedScript.Events = append(customizedScript.Events, customization)
+		}
+	}
+
+	if len(customizedScript.RequestHeaders) == 0 && len(customizedScript.Requests) == 0 && len(customizedScript.Events) == 0 {
+		return nil
+	}
+
+	return &customizedScript
+}
@@ -0,0 +1,108 @@
+package connector
+
+import (
+	"context"
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewriteUrl(t *testing.T) {
+	tests := []struct {
+		name        string
+		originalUrl string
+		baseUrl     string
+		want        string
+	}{
+		{
+			name:        "host is replaced",
+			originalUrl: "https://easytravel.example.com/api/login?user=test",
+			baseUrl:     "http://pr-42.review.example.com:8080",
+			want:        "http://pr-42.review.example.com:8080/api/login?user=test",
+		},
+		{
+			name:        "path of base URL is prepended",
+			originalUrl: "https://easytravel.example.com/health",
+			baseUrl:     "https://review.example.com/pr-42/",
+			want:        "https://review.example.com/pr-42/health",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			baseUrl, err := parseBaseUrl(tt.baseUrl)
+			assert.NoError(t, err)
+
+			got, err := rewriteUrl(tt.originalUrl, baseUrl)
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseBaseUrl(t *testing.T) {
+	_, err := parseBaseUrl("$LABEL.reviewUrl")
+	assert.EqualError(t, err, "invalid baseUrl '$LABEL.reviewUrl': must be an absolute http or https URL")
+
+	_, err = parseBaseUrl("ftp://example.com")
+	assert.Error(t, err)
+}
+
+func TestCreateUrlOverrides(t *testing.T) {
+	baseUrl, err := parseBaseUrl("https://pr-42.example.com")
+	assert.NoError(t, err)
+
+	monitor := dynatrace.SyntheticMonitor{
+		EntityID: "SYNTHETIC_TEST-1",
+		Script: dynatrace.SyntheticMonitorScript{
+			Events: []dynatrace.SyntheticMonitorEvent{
+				{Type: "navigate", URL: "https://easytravel.example.com/"},
+				{Type: "click"},
+				{Type: "navigate", URL: "https://easytravel.example.com/booking"},
+			},
+		},
+	}
+
+	urlOverrides, err := createUrlOverrides(monitor, baseUrl)
+	assert.NoError(t, err)
+	assert.Equal(t, []UrlOverride{
+		{MonitorId: "SYNTHETIC_TEST-1", Type: UrlOverrideTypeEvent, Id: 1, OriginalUrl: "https://easytravel.example.com/", Url: "https://pr-42.example.com/"},
+		{MonitorId: "SYNTHETIC_TEST-1", Type: UrlOverrideTypeEvent, Id: 3, OriginalUrl: "https://easytravel.example.com/booking", Url: "https://pr-42.example.com/booking"},
+	}, urlOverrides)
+}
+
+func TestGetCustomizedScript(t *testing.T) {
+	urlOverrides := []UrlOverride{
+		{MonitorId: "HTTP_CHECK-1", Type: UrlOverrideTypeRequest, Id: 1, Url: "https://pr-42.example.com/health"},
+		{MonitorId: "SYNTHETIC_TEST-2", Type: UrlOverrideTypeEvent, Id: 1, Url: "https://pr-42.example.com/"},
+	}
+	options := ExecutionOptions{CustomizedScript: &CustomizedScript{RequestHeaders: []RequestHeader{{Name: "X-Test", Value: "true"}}}}
+
+	assert.Equal(t, &CustomizedScript{
+		RequestHeaders: []RequestHeader{{Name: "X-Test", Value: "true"}},
+		Requests:       []ScriptUrlCustomization{{Id: 1, Url: "https://pr-42.example.com/health"}},
+	}, getCustomizedScript("HTTP_CHECK-1", options, urlOverrides))
+
+	assert.Equal(t, &CustomizedScript{
+		Events: []ScriptUrlCustomization{{Id: 1, Url: "https://pr-42.example.com/"}},
+	}, getCustomizedScript("SYNTHETIC_TEST-2", ExecutionOptions{}, urlOverrides))
+
+	assert.Nil(t, getCustomizedScript("HTTP_CHECK-3", ExecutionOptions{}, urlOverrides))
+}
+
+func TestSyntheticConnector_generateExecutionEvent_WithBaseUrl(t *testing.T) {
+	handler := test.NewPayloadBasedURLHandler(t)
+	handler.AddExact("/api/v1/synthetic/monitors?tag=smoke", []byte(`{"monitors":[{"entityId":"HTTP_CHECK-1"}]}`))
+	handler.AddExact("/api/v1/synthetic/monitors/HTTP_CHECK-1", []byte(`{"entityId":"HTTP_CHECK-1","type":"HTTP","script":{"requests":[{"url":"https://easytravel.example.com/health","method":"GET"}]}}`))
+	sc, teardown := createSyntheticConnector(t, handler)
+	defer teardown()
+
+	jsonData, urlOverrides, err := sc.generateExecutionEvent(context.TODO(), MonitorSelection{MonitorTags: []string{"smoke"}}, nil, ExecutionOptions{BaseUrl: "https://pr-42.example.com"})
+
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"monitors":[{"monitorId":"HTTP_CHECK-1","locations":[],"customizedScript":{"requests":[{"id":1,"url":"https://pr-42.example.com/health"}]}}]}`, string(jsonData))
+	assert.Equal(t, []UrlOverride{
+		{MonitorId: "HTTP_CHECK-1", Type: UrlOverrideTypeRequest, Id: 1, OriginalUrl: "https://easytravel.example.com/health", Url: "https://pr-42.example.com/health"},
+	}, urlOverrides)
+}
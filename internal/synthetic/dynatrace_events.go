@@ -0,0 +1,69 @@
+package synthetic
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// sendBatchStartedEvent sends an info event to Dynatrace once the batch has been triggered, so that the synthetic test shows up in the event timeline.
+func (eh *SyntheticTriggerEventHandler) sendBatchStartedEvent(workCtx context.Context, executionData connector.ExecutionData) {
+	infoEvent := dynatrace.InfoEvent{
+		EventType:        dynatrace.InfoEventType,
+		Source:           common.EventSource,
+		Title:            "Synthetic batch started",
+		Description:      fmt.Sprintf("Triggered synthetic batch %s with %d monitors for service %s", executionData.BatchId, len(executionData.MonitorIds), eh.event.GetService()),
+		CustomProperties: eh.createBatchCustomProperties(workCtx, executionData),
+		AttachRules:      eh.createBatchAttachRules(executionData),
+	}
+
+	dynatrace.NewEventsClient(eh.dtClient).AddInfoEvent(workCtx, infoEvent)
+}
+
+// sendBatchFinishedEvent sends an info event to Dynatrace once the execution of the batch, including all retries, has finished, if it was waited for.
+func (eh *SyntheticTriggerEventHandler) sendBatchFinishedEvent(replyCtx context.Context, executionData connector.ExecutionData, result keptnv2.ResultType) {
+	if executionData.BatchId == "" || eh.startTime.IsZero() {
+		return
+	}
+
+	customProperties := eh.createBatchCustomProperties(replyCtx, executionData)
+	customProperties["SuccessRate"] = fmt.Sprintf("%.2f", executionData.SuccessRate)
+	customProperties["Result"] = string(result)
+
+	infoEvent := dynatrace.InfoEvent{
+		EventType:        dynatrace.InfoEventType,
+		Source:           common.EventSource,
+		Title:            "Synthetic batch finished",
+		Description:      fmt.Sprintf("Synthetic batch %s finished with result %s and a success rate of %.2f%%", executionData.BatchId, result, executionData.SuccessRate),
+		CustomProperties: customProperties,
+		AttachRules:      eh.createBatchAttachRules(executionData),
+	}
+
+	dynatrace.NewEventsClient(eh.dtClient).AddInfoEvent(replyCtx, infoEvent)
+}
+
+// createBatchAttachRules attaches events to the triggered monitors as well as to the entities matching the configured attach rules, i.e. the Keptn service by default.
+// Checks executed by the service itself are no Dynatrace entities, so events are not attached to them.
+func (eh *SyntheticTriggerEventHandler) createBatchAttachRules(executionData connector.ExecutionData) dynatrace.AttachRules {
+	attachRules := dynatrace.AttachRules{}
+	for _, monitorId := range executionData.MonitorIds {
+		if connector.IsMonitorId(monitorId) {
+			attachRules.EntityIds = append(attachRules.EntityIds, monitorId)
+		}
+	}
+
+	if eh.attachRules != nil {
+		attachRules.TagRule = eh.attachRules.TagRule
+	}
+
+	return attachRules
+}
+
+func (eh *SyntheticTriggerEventHandler) createBatchCustomProperties(ctx context.Context, executionData connector.ExecutionData) map[string]string {
+	return common.CreateCustomProperties(eh.event, map[string]string{"BatchId": executionData.BatchId}, keptn.TryGetBridgeURLForKeptnContext(ctx, eh.event))
+}
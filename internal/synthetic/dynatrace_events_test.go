@@ -0,0 +1,72 @@
+package synthetic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSyntheticTriggerEventHandler_sendBatchFinishedEvent tests that the event is attached to the monitors and the configured entities and that it is only sent if the batch was waited for.
+func TestSyntheticTriggerEventHandler_sendBatchFinishedEvent(t *testing.T) {
+	serviceTagRule := dynatrace.TagRule{
+		MeTypes: []string{"SERVICE"},
+		Tags:    []dynatrace.TagEntry{{Context: "CONTEXTLESS", Key: "keptn_service", Value: "frontend"}},
+	}
+
+	executionData := connector.ExecutionData{
+		BatchId:     "1",
+		MonitorIds:  []string{"SYNTHETIC_TEST-1", "HTTP_CHECK-1"},
+		SuccessRate: 75,
+	}
+
+	tests := []struct {
+		name           string
+		startTime      time.Time
+		executionData  connector.ExecutionData
+		wantInfoEvents int
+	}{
+		{
+			name:           "batch waited for",
+			startTime:      time.Date(2022, 4, 15, 10, 0, 0, 0, time.UTC),
+			executionData:  executionData,
+			wantInfoEvents: 1,
+		},
+		{
+			name:           "batch not waited for",
+			executionData:  executionData,
+			wantInfoEvents: 0,
+		},
+		{
+			name:           "no batch triggered",
+			startTime:      time.Date(2022, 4, 15, 10, 0, 0, 0, time.UTC),
+			executionData:  connector.ExecutionData{},
+			wantInfoEvents: 0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dtClient := newDynatraceClientMock()
+			event := createTestSyntheticTriggerAdapter(t, map[string]interface{}{"monitorId": "HTTP_CHECK-1"})
+
+			handler := NewSyntheticTriggerEventHandler(event, dtClient, nil, nil, nil, nil, nil, nil, &dynatrace.AttachRules{TagRule: []dynatrace.TagRule{serviceTagRule}}, nil)
+			handler.startTime = tt.startTime
+			handler.sendBatchFinishedEvent(context.TODO(), tt.executionData, keptnv2.ResultWarning)
+
+			infoEvents := dtClient.getInfoEvents(t)
+			if !assert.Len(t, infoEvents, tt.wantInfoEvents) || tt.wantInfoEvents == 0 {
+				return
+			}
+
+			assert.Equal(t, dynatrace.AttachRules{EntityIds: []string{"SYNTHETIC_TEST-1", "HTTP_CHECK-1"}, TagRule: []dynatrace.TagRule{serviceTagRule}}, infoEvents[0].AttachRules)
+			assert.Equal(t, "1", infoEvents[0].CustomProperties["BatchId"])
+			assert.Equal(t, "75.00", infoEvents[0].CustomProperties["SuccessRate"])
+			assert.Equal(t, "warning", infoEvents[0].CustomProperties["Result"])
+			assert.Equal(t, "context-1", infoEvents[0].CustomProperties["KeptnContext"])
+		})
+	}
+}
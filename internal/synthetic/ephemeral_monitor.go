@@ -0,0 +1,72 @@
+package synthetic
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	log "github.com/sirupsen/logrus"
+)
+
+// keptnEphemeralTag marks monitors created for a single test only
+const keptnEphemeralTag = "keptn_ephemeral"
+
+// EphemeralMonitor reports the HTTP monitor created for a single test. DeletedMonitorId is empty if the monitor could not be deleted.
+type EphemeralMonitor struct {
+	CreatedMonitorId string `json:"createdMonitorId"`
+	DeletedMonitorId string `json:"deletedMonitorId,omitempty"`
+}
+
+// newEphemeralMonitorDeclaration applies the Keptn placeholders to the template and disables scheduled executions, so the monitor is only executed on demand.
+func newEphemeralMonitorDeclaration(template config.SyntheticMonitorDeclaration, event adapter.EventContentAdapter) (config.SyntheticMonitorDeclaration, error) {
+	declaration := config.ReplacePlaceholdersInSyntheticMonitorDeclaration(template, event)
+
+	onDemandOnly := 0
+	declaration.Frequency = &onDemandOnly
+
+	err := declaration.Validate()
+	if err != nil {
+		return config.SyntheticMonitorDeclaration{}, fmt.Errorf("invalid ephemeral monitor: %w", err)
+	}
+
+	return declaration, nil
+}
+
+// getEphemeralMonitorTags returns the tags identifying a monitor created for the test of the Keptn context
+func getEphemeralMonitorTags(event adapter.EventContentAdapter) []string {
+	return append([]string{keptnEphemeralTag, "keptn_context:" + event.GetShKeptnContext()}, getKeptnTags(event.GetProject(), event.GetStage(), event.GetService())...)
+}
+
+// createEphemeralMonitor creates the HTTP monitor defined by the template for this test only
+func createEphemeralMonitor(workCtx context.Context, monitorsClient *dynatrace.SyntheticMonitorsClient, template config.SyntheticMonitorDeclaration, event adapter.EventContentAdapter) (*EphemeralMonitor, error) {
+	declaration, err := newEphemeralMonitorDeclaration(template, event)
+	if err != nil {
+		return nil, err
+	}
+
+	monitorId, err := monitorsClient.CreateHTTPMonitor(workCtx, newHTTPMonitorDefinition(declaration, getEphemeralMonitorTags(event)))
+	if err != nil {
+		return nil, err
+	}
+
+	log.WithField("monitorId", monitorId).Infof("Created ephemeral synthetic monitor '%s'", declaration.Name)
+	return &EphemeralMonitor{CreatedMonitorId: monitorId}, nil
+}
+
+// deleteEphemeralMonitor deletes the monitor unless it has already been deleted.
+func deleteEphemeralMonitor(replyCtx context.Context, monitorsClient *dynatrace.SyntheticMonitorsClient, monitor *EphemeralMonitor) {
+	if monitor == nil || monitor.DeletedMonitorId != "" {
+		return
+	}
+
+	err := monitorsClient.DeleteByID(replyCtx, monitor.CreatedMonitorId)
+	if err != nil {
+		log.WithError(err).WithField("monitorId", monitor.CreatedMonitorId).Error("Could not delete ephemeral synthetic monitor")
+		return
+	}
+
+	log.WithField("monitorId", monitor.CreatedMonitorId).Info("Deleted ephemeral synthetic monitor")
+	monitor.DeletedMonitorId = monitor.CreatedMonitorId
+}
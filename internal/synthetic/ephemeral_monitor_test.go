@@ -0,0 +1,53 @@
+package synthetic
+
+import (
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/test"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewEphemeralMonitorDeclaration(t *testing.T) {
+	event := &test.EventData{
+		Context: "01234567-0123-0123-0123-012345678901",
+		Project: "easytravel",
+		Stage:   "review",
+		Service: "frontend",
+	}
+
+	frequency := 15
+	template := config.SyntheticMonitorDeclaration{
+		Name:      "$SERVICE $CONTEXT",
+		Frequency: &frequency,
+		Locations: []string{"GEOLOCATION-1"},
+		Requests: []config.SyntheticRequestDeclaration{
+			{Url: "https://$SERVICE.$STAGE.example.com/health"},
+		},
+	}
+
+	declaration, err := newEphemeralMonitorDeclaration(template, event)
+
+	onDemandOnly := 0
+	assert.NoError(t, err)
+	assert.Equal(t, config.SyntheticMonitorDeclaration{
+		Name:      "frontend 01234567-0123-0123-0123-012345678901",
+		Frequency: &onDemandOnly,
+		Locations: []string{"GEOLOCATION-1"},
+		Requests: []config.SyntheticRequestDeclaration{
+			{Url: "https://frontend.review.example.com/health"},
+		},
+	}, declaration)
+	assert.Equal(t, 15, frequency, "template must not be modified")
+
+	assert.Equal(t, []string{
+		"keptn_ephemeral",
+		"keptn_context:01234567-0123-0123-0123-012345678901",
+		"keptn_project:easytravel",
+		"keptn_stage:review",
+		"keptn_service:frontend",
+	}, getEphemeralMonitorTags(event))
+
+	_, err = newEphemeralMonitorDeclaration(config.SyntheticMonitorDeclaration{Name: "health check"}, event)
+	assert.EqualError(t, err, "invalid ephemeral monitor: monitor 'health check' has no locations")
+}
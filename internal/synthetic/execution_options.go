@@ -0,0 +1,82 @@
+package synthetic
+
+import (
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+)
+
+// newExecutionOptions creates validated connector.ExecutionOptions based on the specified options, each overriding the options set before.
+func newExecutionOptions(executionOptions ...config.SyntheticExecutionOptions) (connector.ExecutionOptions, error) {
+	merged := config.SyntheticExecutionOptions{}
+	for _, options := range executionOptions {
+		merged = overrideExecutionOptions(merged, options)
+	}
+
+	options := connector.ExecutionOptions{
+		ProcessingMode:           merged.ProcessingMode,
+		FailOnPerformanceIssue:   merged.FailOnPerformanceIssue,
+		FailOnSslWarning:         merged.FailOnSslWarning,
+		StopOnProblem:            merged.StopOnProblem,
+		TakeScreenshotsOnSuccess: merged.TakeScreenshotsOnSuccess,
+		CustomizedScript:         newCustomizedScript(merged.CustomizedScript),
+		BaseUrl:                  merged.BaseUrl,
+	}
+
+	err := options.Validate()
+	if err != nil {
+		return connector.ExecutionOptions{}, err
+	}
+
+	return options, nil
+}
+
+// overrideExecutionOptions returns the base options with all options set in override replacing the respective base options.
+func overrideExecutionOptions(base config.SyntheticExecutionOptions, override config.SyntheticExecutionOptions) config.SyntheticExecutionOptions {
+	if override.ProcessingMode != "" {
+		base.ProcessingMode = override.ProcessingMode
+	}
+
+	if override.FailOnPerformanceIssue != nil {
+		base.FailOnPerformanceIssue = override.FailOnPerformanceIssue
+	}
+
+	if override.FailOnSslWarning != nil {
+		base.FailOnSslWarning = override.FailOnSslWarning
+	}
+
+	if override.StopOnProblem != nil {
+		base.StopOnProblem = override.StopOnProblem
+	}
+
+	if override.TakeScreenshotsOnSuccess != nil {
+		base.TakeScreenshotsOnSuccess = override.TakeScreenshotsOnSuccess
+	}
+
+	if override.CustomizedScript != nil {
+		base.CustomizedScript = override.CustomizedScript
+	}
+
+	if override.BaseUrl != "" {
+		base.BaseUrl = override.BaseUrl
+	}
+
+	return base
+}
+
+func newCustomizedScript(customizedScript *config.SyntheticCustomizedScript) *connector.CustomizedScript {
+	if customizedScript == nil || len(customizedScript.RequestHeaders) == 0 {
+		return nil
+	}
+
+	requestHeaders := make([]connector.RequestHeader, 0, len(customizedScript.RequestHeaders))
+	for _, requestHeader := range customizedScript.RequestHeaders {
+		requestHeaders = append(requestHeaders, connector.RequestHeader{
+			Name:  requestHeader.Name,
+			Value: requestHeader.Value,
+		})
+	}
+
+	return &connector.CustomizedScript{
+		RequestHeaders: requestHeaders,
+	}
+}
@@ -0,0 +1,45 @@
+package synthetic
+
+import (
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewExecutionOptions(t *testing.T) {
+	enabled := true
+	disabled := false
+
+	configOptions := config.SyntheticExecutionOptions{
+		ProcessingMode:         "standard",
+		FailOnPerformanceIssue: &enabled,
+		StopOnProblem:          &enabled,
+		CustomizedScript: &config.SyntheticCustomizedScript{
+			RequestHeaders: []config.SyntheticRequestHeader{{Name: "Authorization", Value: "Bearer token"}},
+		},
+	}
+	eventOptions := config.SyntheticExecutionOptions{
+		ProcessingMode: "disable_problem_detection",
+		StopOnProblem:  &disabled,
+	}
+
+	options, err := newExecutionOptions(configOptions, eventOptions)
+	assert.NoError(t, err)
+	assert.Equal(t, connector.ExecutionOptions{
+		ProcessingMode:         connector.ProcessingModeDisableProblemDetection,
+		FailOnPerformanceIssue: &enabled,
+		StopOnProblem:          &disabled,
+		CustomizedScript: &connector.CustomizedScript{
+			RequestHeaders: []connector.RequestHeader{{Name: "Authorization", Value: "Bearer token"}},
+		},
+	}, options)
+
+	options, err = newExecutionOptions()
+	assert.NoError(t, err)
+	assert.Equal(t, connector.ExecutionOptions{}, options)
+
+	_, err = newExecutionOptions(config.SyntheticExecutionOptions{ProcessingMode: "fast"})
+	assert.Error(t, err)
+}
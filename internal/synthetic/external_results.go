@@ -0,0 +1,268 @@
+package synthetic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+)
+
+const (
+	// ExternalResultsFormatJUnit is the format of test results provided as JUnit XML report
+	ExternalResultsFormatJUnit = "junit"
+	// ExternalResultsFormatK6 is the format of test results provided as k6 summary, i.e. the output of --summary-export or of a handleSummary function
+	ExternalResultsFormatK6 = "k6"
+)
+
+const defaultExternalLocationName = "Keptn"
+const externalTestScheduleIntervalInSeconds = 3600
+
+// externalStepFailedErrorCode is reported for failed steps without an error code, e.g. failed JUnit test cases
+const externalStepFailedErrorCode = 1
+
+var nonIDCharactersRegex = regexp.MustCompile(`[^a-z0-9]+`)
+
+// ExternalTestResults are the results of tests executed outside of Dynatrace Synthetic, e.g. by k6 or Postman in the pipeline.
+type ExternalTestResults struct {
+	// Engine is the name of the tool executing the tests, e.g. k6 or Postman
+	Engine string `json:"engine"`
+	// Location is the name of the location the tests were executed from, Keptn by default
+	Location string         `json:"location"`
+	Tests    []ExternalTest `json:"tests"`
+}
+
+// ExternalTest is a test reported as third-party synthetic monitor.
+type ExternalTest struct {
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Steps       []ExternalTestStep `json:"steps"`
+}
+
+// ExternalTestStep is a step of a test reported as step of a third-party synthetic monitor.
+type ExternalTestStep struct {
+	Name    string `json:"name"`
+	Success bool   `json:"success"`
+	// StartTimestamp is the start of the step in milliseconds since the epoch, by default the step starts after the previous step of the test
+	StartTimestamp int64  `json:"startTimestamp"`
+	DurationMillis int64  `json:"durationMillis"`
+	ErrorCode      int    `json:"errorCode"`
+	ErrorMessage   string `json:"errorMessage"`
+}
+
+// ExternalTestResultsDeclaration declares the results of tests executed outside of Dynatrace Synthetic in a test finished event.
+// The results are either specified inline or stored in a Keptn resource.
+type ExternalTestResultsDeclaration struct {
+	ExternalTestResults
+	// Resource is the URI of a Keptn resource containing the results, looked up on service, stage and project level
+	Resource string `json:"resource"`
+	// Format is the format of the resource, i.e. junit or k6, by default derived from its file extension
+	Format string `json:"format"`
+}
+
+// getResourceFormat gets the format of the resource, i.e. junit for .xml files and k6 for .json files if not specified explicitly.
+func (d ExternalTestResultsDeclaration) getResourceFormat() (string, error) {
+	if d.Format != "" {
+		return strings.ToLower(d.Format), nil
+	}
+
+	switch strings.ToLower(path.Ext(d.Resource)) {
+	case ".xml":
+		return ExternalResultsFormatJUnit, nil
+	case ".json":
+		return ExternalResultsFormatK6, nil
+	default:
+		return "", fmt.Errorf("could not derive the format of test results resource '%s', please specify it", d.Resource)
+	}
+}
+
+// parseExternalTestResults parses test results provided as resource in the specified format.
+// The engine and location of the declaration take precedence over the ones derived from the resource.
+func parseExternalTestResults(declaration ExternalTestResultsDeclaration, content []byte) (*ExternalTestResults, error) {
+	format, err := declaration.getResourceFormat()
+	if err != nil {
+		return nil, err
+	}
+
+	var results *ExternalTestResults
+	switch format {
+	case ExternalResultsFormatJUnit:
+		results, err = parseJUnitResults(content)
+	case ExternalResultsFormatK6:
+		results, err = parseK6Summary(content, strings.TrimSuffix(path.Base(declaration.Resource), path.Ext(declaration.Resource)))
+	default:
+		return nil, fmt.Errorf("unsupported test results format '%s', supported formats are %s and %s", format, ExternalResultsFormatJUnit, ExternalResultsFormatK6)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("could not parse test results resource '%s': %w", declaration.Resource, err)
+	}
+
+	if declaration.Engine != "" {
+		results.Engine = declaration.Engine
+	}
+	if declaration.Location != "" {
+		results.Location = declaration.Location
+	}
+
+	return results, nil
+}
+
+// validate checks that the results can be reported as third-party synthetic monitors.
+func (r ExternalTestResults) validate() error {
+	if r.Engine == "" {
+		return errors.New("test results must specify an engine")
+	}
+
+	if len(r.Tests) == 0 {
+		return errors.New("test results must contain at least one test")
+	}
+
+	for _, test := range r.Tests {
+		if test.Name == "" {
+			return errors.New("all tests must have a name")
+		}
+
+		if len(test.Steps) == 0 {
+			return fmt.Errorf("test '%s' must contain at least one step", test.Name)
+		}
+	}
+
+	return nil
+}
+
+// newThirdPartySyntheticTests maps the test results to third-party synthetic monitors of the Keptn service and their results.
+// Steps without a start timestamp start after the previous step of their test, the first step at start.
+func newThirdPartySyntheticTests(ctx context.Context, event adapter.EventContentAdapter, results ExternalTestResults, start time.Time, now time.Time) (dynatrace.ThirdPartySyntheticTests, error) {
+	if err := results.validate(); err != nil {
+		return dynatrace.ThirdPartySyntheticTests{}, err
+	}
+
+	locationName := results.Location
+	if locationName == "" {
+		locationName = defaultExternalLocationName
+	}
+	location := dynatrace.ThirdPartySyntheticLocation{
+		ID:   getExternalLocationID(locationName),
+		Name: locationName,
+	}
+
+	drilldownLink := keptn.TryGetBridgeURLForKeptnContext(ctx, event)
+
+	tests := dynatrace.ThirdPartySyntheticTests{
+		MessageTimestamp:    now.UnixMilli(),
+		SyntheticEngineName: results.Engine,
+		Locations:           []dynatrace.ThirdPartySyntheticLocation{location},
+	}
+
+	for _, test := range results.Tests {
+		id := getExternalTestID(event, results.Engine, test.Name)
+
+		description := test.Description
+		if description == "" {
+			description = fmt.Sprintf("%s test of service %s in stage %s of project %s", results.Engine, event.GetService(), event.GetStage(), event.GetProject())
+		}
+
+		monitor := dynatrace.ThirdPartySyntheticMonitor{
+			ID:                        id,
+			Title:                     test.Name,
+			Description:               description,
+			TestSetup:                 results.Engine,
+			DrilldownLink:             drilldownLink,
+			Enabled:                   true,
+			Locations:                 []dynatrace.ThirdPartySyntheticMonitorLocation{{ID: location.ID, Enabled: true}},
+			ScheduleIntervalInSeconds: externalTestScheduleIntervalInSeconds,
+		}
+
+		locationResult := dynatrace.ThirdPartySyntheticLocationResult{
+			ID:      location.ID,
+			Success: true,
+		}
+
+		nextStepStart := start.UnixMilli()
+		for i, step := range test.Steps {
+			monitor.Steps = append(monitor.Steps, dynatrace.ThirdPartySyntheticMonitorStep{ID: i + 1, Title: step.Name})
+
+			stepStart := step.StartTimestamp
+			if stepStart == 0 {
+				stepStart = nextStepStart
+			}
+			nextStepStart = stepStart + step.DurationMillis
+
+			stepResult := dynatrace.ThirdPartySyntheticStepResult{
+				ID:                 i + 1,
+				StartTimestamp:     stepStart,
+				ResponseTimeMillis: step.DurationMillis,
+			}
+
+			if !step.Success {
+				stepResult.Error = newThirdPartySyntheticError(step)
+				locationResult.Success = false
+			}
+
+			if i == 0 {
+				locationResult.StartTimestamp = stepStart
+			}
+			locationResult.ResponseTimeMillis += step.DurationMillis
+			locationResult.StepResults = append(locationResult.StepResults, stepResult)
+		}
+
+		tests.Tests = append(tests.Tests, monitor)
+		tests.TestResults = append(tests.TestResults, dynatrace.ThirdPartySyntheticResult{
+			ID:                        id,
+			ScheduleIntervalInSeconds: externalTestScheduleIntervalInSeconds,
+			TotalStepCount:            len(test.Steps),
+			LocationResults:           []dynatrace.ThirdPartySyntheticLocationResult{locationResult},
+		})
+	}
+
+	return tests, nil
+}
+
+func newThirdPartySyntheticError(step ExternalTestStep) *dynatrace.ThirdPartySyntheticError {
+	syntheticError := &dynatrace.ThirdPartySyntheticError{
+		Code:    step.ErrorCode,
+		Message: step.ErrorMessage,
+	}
+
+	if syntheticError.Code == 0 {
+		syntheticError.Code = externalStepFailedErrorCode
+	}
+
+	if syntheticError.Message == "" {
+		syntheticError.Message = fmt.Sprintf("step '%s' failed", step.Name)
+	}
+
+	return syntheticError
+}
+
+// getExternalTestID gets the ID of the third-party synthetic monitor of a test of the Keptn service executed by the engine.
+func getExternalTestID(event adapter.EventContentAdapter, engine string, name string) string {
+	return getExternalID("keptn", event.GetProject(), event.GetStage(), event.GetService(), engine, name)
+}
+
+// getExternalLocationID gets the ID of the location with the specified name, i.e. of the default location if the name is empty.
+func getExternalLocationID(name string) string {
+	if name == "" {
+		name = defaultExternalLocationName
+	}
+	return getExternalID(name)
+}
+
+// getExternalID creates a stable ID from the specified parts, so that repeated results of a test are reported for the same third-party synthetic monitor.
+func getExternalID(parts ...string) string {
+	ids := make([]string, 0, len(parts))
+	for _, part := range parts {
+		id := strings.Trim(nonIDCharactersRegex.ReplaceAllString(strings.ToLower(part), "-"), "-")
+		if id != "" {
+			ids = append(ids, id)
+		}
+	}
+
+	return strings.Join(ids, "-")
+}
@@ -0,0 +1,151 @@
+package synthetic
+
+import (
+	"encoding/xml"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const junitEngineName = "JUnit"
+
+type junitTestSuites struct {
+	XMLName xml.Name         `xml:""`
+	Suites  []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name      string           `xml:"name,attr"`
+	Timestamp string           `xml:"timestamp,attr"`
+	Cases     []junitTestCase  `xml:"testcase"`
+	Suites    []junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitProblem `xml:"failure"`
+	Error     *junitProblem `xml:"error"`
+	Skipped   *struct{}     `xml:"skipped"`
+}
+
+type junitProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr"`
+	Text    string `xml:",chardata"`
+}
+
+// parseJUnitResults parses a JUnit XML report, with either a testsuites or a testsuite root element.
+// Each test suite containing test cases is reported as test and each executed test case as one of its steps, skipped test cases are ignored.
+func parseJUnitResults(content []byte) (*ExternalTestResults, error) {
+	root := junitTestSuites{}
+	if err := xml.Unmarshal(content, &root); err != nil {
+		return nil, err
+	}
+
+	suites := root.Suites
+	switch root.XMLName.Local {
+	case "testsuites":
+	case "testsuite":
+		suite := junitTestSuite{}
+		if err := xml.Unmarshal(content, &suite); err != nil {
+			return nil, err
+		}
+		suites = []junitTestSuite{suite}
+	default:
+		return nil, errors.New("root element must either be testsuites or testsuite")
+	}
+
+	results := &ExternalTestResults{Engine: junitEngineName}
+	for _, suite := range flattenJUnitTestSuites(suites) {
+		if test, ok := newJUnitTest(suite); ok {
+			results.Tests = append(results.Tests, test)
+		}
+	}
+
+	return results, nil
+}
+
+func flattenJUnitTestSuites(suites []junitTestSuite) []junitTestSuite {
+	var flattened []junitTestSuite
+	for _, suite := range suites {
+		flattened = append(flattened, suite)
+		flattened = append(flattened, flattenJUnitTestSuites(suite.Suites)...)
+	}
+	return flattened
+}
+
+func newJUnitTest(suite junitTestSuite) (ExternalTest, bool) {
+	test := ExternalTest{Name: suite.Name}
+
+	var nextStepStart int64
+	if timestamp, ok := parseJUnitTimestamp(suite.Timestamp); ok {
+		nextStepStart = timestamp.UnixMilli()
+	}
+
+	for _, testCase := range suite.Cases {
+		if testCase.Skipped != nil {
+			continue
+		}
+
+		step := ExternalTestStep{
+			Name:           testCase.Name,
+			Success:        true,
+			DurationMillis: parseJUnitDurationMillis(testCase.Time),
+		}
+
+		if test.Name == "" {
+			test.Name = testCase.ClassName
+		}
+
+		if nextStepStart != 0 {
+			step.StartTimestamp = nextStepStart
+			nextStepStart += step.DurationMillis
+		}
+
+		for _, problem := range []*junitProblem{testCase.Failure, testCase.Error} {
+			if problem != nil && step.Success {
+				step.Success = false
+				step.ErrorMessage = problem.getMessage()
+			}
+		}
+
+		test.Steps = append(test.Steps, step)
+	}
+
+	return test, len(test.Steps) > 0
+}
+
+func (p junitProblem) getMessage() string {
+	if p.Message != "" {
+		return p.Message
+	}
+
+	if text := strings.TrimSpace(p.Text); text != "" {
+		return text
+	}
+
+	return p.Type
+}
+
+// parseJUnitTimestamp parses the timestamp of a test suite, which is typically specified without a time zone and therefore interpreted as UTC.
+func parseJUnitTimestamp(timestamp string) (time.Time, bool) {
+	for _, layout := range []string{time.RFC3339Nano, "2006-01-02T15:04:05.999999999"} {
+		if t, err := time.Parse(layout, timestamp); err == nil {
+			return t, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseJUnitDurationMillis parses the duration of a test case specified in seconds, e.g. 1.234 or 1,234.5.
+func parseJUnitDurationMillis(seconds string) int64 {
+	value, err := strconv.ParseFloat(strings.ReplaceAll(seconds, ",", ""), 64)
+	if err != nil || value < 0 {
+		return 0
+	}
+	return int64(math.Round(value * 1000))
+}
@@ -0,0 +1,171 @@
+package synthetic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+const k6EngineName = "k6"
+const k6ResponseTimeMetric = "http_req_duration"
+
+// k6Summary is a k6 summary, either exported via --summary-export, where groups and checks are maps, or passed to a handleSummary function, where they are arrays.
+type k6Summary struct {
+	RootGroup k6Group                    `json:"root_group"`
+	Metrics   map[string]json.RawMessage `json:"metrics"`
+}
+
+type k6Group struct {
+	Name   string          `json:"name"`
+	Groups json.RawMessage `json:"groups"`
+	Checks json.RawMessage `json:"checks"`
+}
+
+type k6Check struct {
+	Name   string `json:"name"`
+	Passes int    `json:"passes"`
+	Fails  int    `json:"fails"`
+}
+
+type k6TrendMetric struct {
+	Avg    *float64 `json:"avg"`
+	Values struct {
+		Avg *float64 `json:"avg"`
+	} `json:"values"`
+}
+
+// parseK6Summary parses a k6 summary, which is reported as a single test with the specified name.
+// Each check, including the checks of nested groups, is reported as step, which is successful if the check never failed.
+// As k6 does not time checks, all steps are reported with the average HTTP request duration.
+func parseK6Summary(content []byte, name string) (*ExternalTestResults, error) {
+	summary := k6Summary{}
+	if err := json.Unmarshal(content, &summary); err != nil {
+		return nil, err
+	}
+
+	checks, err := summary.RootGroup.getChecks("")
+	if err != nil {
+		return nil, err
+	}
+
+	if len(checks) == 0 {
+		return nil, errors.New("k6 summary contains no checks")
+	}
+
+	durationMillis, err := summary.getAverageResponseTimeMillis()
+	if err != nil {
+		return nil, err
+	}
+
+	test := ExternalTest{Name: name}
+	for _, check := range checks {
+		step := ExternalTestStep{
+			Name:           check.Name,
+			Success:        check.Fails == 0,
+			DurationMillis: durationMillis,
+		}
+
+		if !step.Success {
+			step.ErrorMessage = fmt.Sprintf("%d of %d checks failed", check.Fails, check.Passes+check.Fails)
+		}
+
+		test.Steps = append(test.Steps, step)
+	}
+
+	return &ExternalTestResults{
+		Engine: k6EngineName,
+		Tests:  []ExternalTest{test},
+	}, nil
+}
+
+// getChecks gets the checks of the group and its nested groups, the names of checks in nested groups are prefixed with the group names.
+func (g k6Group) getChecks(prefix string) ([]k6Check, error) {
+	if g.Name != "" {
+		prefix = prefix + g.Name + "::"
+	}
+
+	checks := []k6Check{}
+	if err := unmarshalK6MapOrArray(g.Checks, &checks); err != nil {
+		return nil, fmt.Errorf("could not parse checks of group '%s': %w", g.Name, err)
+	}
+
+	for i := range checks {
+		checks[i].Name = prefix + checks[i].Name
+	}
+
+	groups := []k6Group{}
+	if err := unmarshalK6MapOrArray(g.Groups, &groups); err != nil {
+		return nil, fmt.Errorf("could not parse groups of group '%s': %w", g.Name, err)
+	}
+
+	for _, group := range groups {
+		groupChecks, err := group.getChecks(prefix)
+		if err != nil {
+			return nil, err
+		}
+		checks = append(checks, groupChecks...)
+	}
+
+	return checks, nil
+}
+
+func (s k6Summary) getAverageResponseTimeMillis() (int64, error) {
+	rawMetric, ok := s.Metrics[k6ResponseTimeMetric]
+	if !ok {
+		return 0, nil
+	}
+
+	metric := k6TrendMetric{}
+	if err := json.Unmarshal(rawMetric, &metric); err != nil {
+		return 0, fmt.Errorf("could not parse metric %s: %w", k6ResponseTimeMetric, err)
+	}
+
+	avg := metric.Avg
+	if avg == nil {
+		avg = metric.Values.Avg
+	}
+
+	if avg == nil {
+		return 0, nil
+	}
+
+	return int64(math.Round(*avg)), nil
+}
+
+// unmarshalK6MapOrArray unmarshals either a JSON array or the values of a JSON object sorted by their keys into target, which must point to a slice.
+func unmarshalK6MapOrArray(data json.RawMessage, target interface{}) error {
+	trimmed := strings.TrimSpace(string(data))
+	if trimmed == "" || trimmed == "null" {
+		return nil
+	}
+
+	if !strings.HasPrefix(trimmed, "{") {
+		return json.Unmarshal(data, target)
+	}
+
+	values := map[string]json.RawMessage{}
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	array := make([]json.RawMessage, 0, len(keys))
+	for _, key := range keys {
+		array = append(array, values[key])
+	}
+
+	arrayData, err := json.Marshal(array)
+	if err != nil {
+		return err
+	}
+
+	return json.Unmarshal(arrayData, target)
+}
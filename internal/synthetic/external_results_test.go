@@ -0,0 +1,247 @@
+package synthetic
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseJUnitResults(t *testing.T) {
+	content := `<?xml version="1.0" encoding="UTF-8"?>
+<testsuites>
+  <testsuite name="checkout" timestamp="2022-04-15T10:00:00">
+    <testcase name="add to cart" time="0.120"/>
+    <testcase name="pay" time="0.35">
+      <failure message="expected 200 but got 500" type="AssertionError">stack trace</failure>
+    </testcase>
+    <testcase name="refund" time="1.0">
+      <skipped/>
+    </testcase>
+  </testsuite>
+  <testsuite name="empty"/>
+  <testsuite name="">
+    <testcase classname="search" name="find hotel" time="2">
+      <error type="TimeoutError"/>
+    </testcase>
+  </testsuite>
+</testsuites>`
+
+	results, err := parseJUnitResults([]byte(content))
+	assert.NoError(t, err)
+
+	start := time.Date(2022, 4, 15, 10, 0, 0, 0, time.UTC).UnixMilli()
+	assert.EqualValues(t, &ExternalTestResults{
+		Engine: "JUnit",
+		Tests: []ExternalTest{
+			{
+				Name: "checkout",
+				Steps: []ExternalTestStep{
+					{Name: "add to cart", Success: true, StartTimestamp: start, DurationMillis: 120},
+					{Name: "pay", Success: false, StartTimestamp: start + 120, DurationMillis: 350, ErrorMessage: "expected 200 but got 500"},
+				},
+			},
+			{
+				Name: "search",
+				Steps: []ExternalTestStep{
+					{Name: "find hotel", Success: false, DurationMillis: 2000, ErrorMessage: "TimeoutError"},
+				},
+			},
+		},
+	}, results)
+}
+
+func TestParseJUnitResults_SingleTestSuite(t *testing.T) {
+	content := `<testsuite name="health"><testcase name="status" time="0.05"/></testsuite>`
+
+	results, err := parseJUnitResults([]byte(content))
+	assert.NoError(t, err)
+	if assert.Len(t, results.Tests, 1) {
+		assert.Equal(t, "health", results.Tests[0].Name)
+		assert.EqualValues(t, []ExternalTestStep{{Name: "status", Success: true, DurationMillis: 50}}, results.Tests[0].Steps)
+	}
+}
+
+func TestParseJUnitResults_InvalidRoot(t *testing.T) {
+	_, err := parseJUnitResults([]byte(`<report/>`))
+	assert.Error(t, err)
+}
+
+func TestParseK6Summary(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{
+			name: "summary export",
+			content: `{
+  "root_group": {
+    "name": "", "path": "", "id": "d41d8cd98f00b204e9800998ecf8427e",
+    "groups": {
+      "login": {
+        "name": "login", "path": "::login", "id": "1",
+        "groups": {},
+        "checks": {"token received": {"name": "token received", "path": "::login::token received", "id": "2", "passes": 8, "fails": 2}}
+      }
+    },
+    "checks": {"status is 200": {"name": "status is 200", "path": "::status is 200", "id": "3", "passes": 10, "fails": 0}}
+  },
+  "metrics": {"http_req_duration": {"avg": 123.6, "min": 50, "max": 300}}
+}`,
+		},
+		{
+			name: "handleSummary data",
+			content: `{
+  "root_group": {
+    "name": "", "path": "", "id": "d41d8cd98f00b204e9800998ecf8427e",
+    "groups": [
+      {
+        "name": "login", "path": "::login", "id": "1",
+        "groups": [],
+        "checks": [{"name": "token received", "path": "::login::token received", "id": "2", "passes": 8, "fails": 2}]
+      }
+    ],
+    "checks": [{"name": "status is 200", "path": "::status is 200", "id": "3", "passes": 10, "fails": 0}]
+  },
+  "metrics": {"http_req_duration": {"type": "trend", "contains": "time", "values": {"avg": 123.6, "min": 50, "max": 300}}}
+}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results, err := parseK6Summary([]byte(tt.content), "checkout")
+			assert.NoError(t, err)
+			assert.EqualValues(t, &ExternalTestResults{
+				Engine: "k6",
+				Tests: []ExternalTest{
+					{
+						Name: "checkout",
+						Steps: []ExternalTestStep{
+							{Name: "status is 200", Success: true, DurationMillis: 124},
+							{Name: "login::token received", Success: false, DurationMillis: 124, ErrorMessage: "2 of 10 checks failed"},
+						},
+					},
+				},
+			}, results)
+		})
+	}
+}
+
+func TestParseK6Summary_NoChecks(t *testing.T) {
+	_, err := parseK6Summary([]byte(`{"root_group": {"name": "", "groups": {}, "checks": {}}, "metrics": {}}`), "checkout")
+	assert.Error(t, err)
+}
+
+func TestParseExternalTestResults(t *testing.T) {
+	declaration := ExternalTestResultsDeclaration{
+		ExternalTestResults: ExternalTestResults{Engine: "Postman", Location: "GitHub Actions"},
+		Resource:            "tests/report.xml",
+	}
+
+	results, err := parseExternalTestResults(declaration, []byte(`<testsuite name="health"><testcase name="status" time="0.05"/></testsuite>`))
+	assert.NoError(t, err)
+	assert.Equal(t, "Postman", results.Engine)
+	assert.Equal(t, "GitHub Actions", results.Location)
+	assert.Len(t, results.Tests, 1)
+
+	results, err = parseExternalTestResults(ExternalTestResultsDeclaration{Resource: "tests/report.xml"}, []byte(`<testsuite name="health"><testcase name="status" time="0.05"/></testsuite>`))
+	assert.NoError(t, err)
+	assert.Equal(t, "JUnit", results.Engine)
+	assert.Empty(t, results.Location)
+
+	_, err = parseExternalTestResults(ExternalTestResultsDeclaration{Resource: "tests/report.txt"}, []byte("passed"))
+	assert.Error(t, err)
+
+	_, err = parseExternalTestResults(ExternalTestResultsDeclaration{Resource: "tests/report.txt", Format: "newman"}, []byte("passed"))
+	assert.Error(t, err)
+}
+
+func TestNewThirdPartySyntheticTests(t *testing.T) {
+	event := createTestSyntheticTriggerAdapter(t, map[string]interface{}{})
+	start := time.Date(2022, 4, 15, 10, 0, 0, 0, time.UTC)
+	now := start.Add(time.Minute)
+
+	results := ExternalTestResults{
+		Engine: "Postman",
+		Tests: []ExternalTest{
+			{
+				Name: "Checkout flow",
+				Steps: []ExternalTestStep{
+					{Name: "add to cart", Success: true, DurationMillis: 120},
+					{Name: "pay", Success: false, DurationMillis: 350, ErrorCode: 500, ErrorMessage: "payment failed"},
+					{Name: "logout", Success: false, StartTimestamp: start.Add(time.Second).UnixMilli(), DurationMillis: 30},
+				},
+			},
+		},
+	}
+
+	tests, err := newThirdPartySyntheticTests(context.TODO(), event, results, start, now)
+	assert.NoError(t, err)
+
+	id := "keptn-easytravel-staging-frontend-postman-checkout-flow"
+	assert.EqualValues(t, dynatrace.ThirdPartySyntheticTests{
+		MessageTimestamp:    now.UnixMilli(),
+		SyntheticEngineName: "Postman",
+		Locations:           []dynatrace.ThirdPartySyntheticLocation{{ID: "keptn", Name: "Keptn"}},
+		Tests: []dynatrace.ThirdPartySyntheticMonitor{
+			{
+				ID:          id,
+				Title:       "Checkout flow",
+				Description: "Postman test of service frontend in stage staging of project easytravel",
+				TestSetup:   "Postman",
+				Enabled:     true,
+				Locations:   []dynatrace.ThirdPartySyntheticMonitorLocation{{ID: "keptn", Enabled: true}},
+				Steps: []dynatrace.ThirdPartySyntheticMonitorStep{
+					{ID: 1, Title: "add to cart"},
+					{ID: 2, Title: "pay"},
+					{ID: 3, Title: "logout"},
+				},
+				ScheduleIntervalInSeconds: 3600,
+			},
+		},
+		TestResults: []dynatrace.ThirdPartySyntheticResult{
+			{
+				ID:                        id,
+				ScheduleIntervalInSeconds: 3600,
+				TotalStepCount:            3,
+				LocationResults: []dynatrace.ThirdPartySyntheticLocationResult{
+					{
+						ID:                 "keptn",
+						StartTimestamp:     start.UnixMilli(),
+						Success:            false,
+						ResponseTimeMillis: 500,
+						StepResults: []dynatrace.ThirdPartySyntheticStepResult{
+							{ID: 1, StartTimestamp: start.UnixMilli(), ResponseTimeMillis: 120},
+							{ID: 2, StartTimestamp: start.UnixMilli() + 120, ResponseTimeMillis: 350, Error: &dynatrace.ThirdPartySyntheticError{Code: 500, Message: "payment failed"}},
+							{ID: 3, StartTimestamp: start.Add(time.Second).UnixMilli(), ResponseTimeMillis: 30, Error: &dynatrace.ThirdPartySyntheticError{Code: 1, Message: "step 'logout' failed"}},
+						},
+					},
+				},
+			},
+		},
+	}, tests)
+}
+
+func TestNewThirdPartySyntheticTests_Invalid(t *testing.T) {
+	event := createTestSyntheticTriggerAdapter(t, map[string]interface{}{})
+
+	tests := []struct {
+		name    string
+		results ExternalTestResults
+	}{
+		{name: "no engine", results: ExternalTestResults{Tests: []ExternalTest{{Name: "a", Steps: []ExternalTestStep{{Name: "b"}}}}}},
+		{name: "no tests", results: ExternalTestResults{Engine: "k6"}},
+		{name: "no test name", results: ExternalTestResults{Engine: "k6", Tests: []ExternalTest{{Steps: []ExternalTestStep{{Name: "b"}}}}}},
+		{name: "no steps", results: ExternalTestResults{Engine: "k6", Tests: []ExternalTest{{Name: "a"}}}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := newThirdPartySyntheticTests(context.TODO(), event, tt.results, time.Now(), time.Now())
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,104 @@
+package synthetic
+
+import (
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+type ExternalTestFinishedAdapterInterface interface {
+	adapter.EventContentAdapter
+
+	GetTestDetails() keptnv2.TestFinishedDetails
+	GetSyntheticResults() *ExternalTestResultsDeclaration
+}
+
+// ExternalTestFinishedEventData is the data of a test finished event of a test executed outside of Dynatrace Synthetic.
+type ExternalTestFinishedEventData struct {
+	keptnv2.EventData
+	Test keptnv2.TestFinishedDetails `json:"test"`
+	// SyntheticResults declares the test results to be reported as third-party synthetic monitors
+	SyntheticResults *ExternalTestResultsDeclaration `json:"syntheticResults"`
+}
+
+// ExternalTestFinishedAdapter is a content adaptor for events of type sh.keptn.event.test.finished declaring third-party test results
+type ExternalTestFinishedAdapter struct {
+	event      ExternalTestFinishedEventData
+	cloudEvent adapter.CloudEventAdapter
+}
+
+// NewExternalTestFinishedAdapterFromEvent creates a new ExternalTestFinishedAdapter from a cloudevents Event
+func NewExternalTestFinishedAdapterFromEvent(e cloudevents.Event) (*ExternalTestFinishedAdapter, error) {
+	ceAdapter := adapter.NewCloudEventAdapter(e)
+
+	tfData := &ExternalTestFinishedEventData{}
+	err := ceAdapter.PayloadAs(tfData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ExternalTestFinishedAdapter{
+		event:      *tfData,
+		cloudEvent: ceAdapter,
+	}, nil
+}
+
+// GetShKeptnContext returns the shkeptncontext
+func (a ExternalTestFinishedAdapter) GetShKeptnContext() string {
+	return a.cloudEvent.GetShKeptnContext()
+}
+
+// GetSource returns the source specified in the CloudEvent context
+func (a ExternalTestFinishedAdapter) GetSource() string {
+	return a.cloudEvent.GetSource()
+}
+
+// GetEvent returns the event type
+func (a ExternalTestFinishedAdapter) GetEvent() string {
+	return keptnv2.GetFinishedEventType(keptnv2.TestTaskName)
+}
+
+// GetProject returns the project
+func (a ExternalTestFinishedAdapter) GetProject() string {
+	return a.event.Project
+}
+
+// GetStage returns the stage
+func (a ExternalTestFinishedAdapter) GetStage() string {
+	return a.event.Stage
+}
+
+// GetService returns the service
+func (a ExternalTestFinishedAdapter) GetService() string {
+	return a.event.Service
+}
+
+// GetDeployment returns the name of the deployment
+func (a ExternalTestFinishedAdapter) GetDeployment() string {
+	return ""
+}
+
+// GetTestStrategy returns the used test strategy
+func (a ExternalTestFinishedAdapter) GetTestStrategy() string {
+	return ""
+}
+
+// GetDeploymentStrategy returns the used deployment strategy
+func (a ExternalTestFinishedAdapter) GetDeploymentStrategy() string {
+	return ""
+}
+
+// GetLabels returns a map of labels
+func (a ExternalTestFinishedAdapter) GetLabels() map[string]string {
+	return a.event.Labels
+}
+
+// GetTestDetails returns the start and end of the test
+func (a ExternalTestFinishedAdapter) GetTestDetails() keptnv2.TestFinishedDetails {
+	return a.event.Test
+}
+
+// GetSyntheticResults returns the declared test results or nil if the event declares none
+func (a ExternalTestFinishedAdapter) GetSyntheticResults() *ExternalTestResultsDeclaration {
+	return a.event.SyntheticResults
+}
@@ -0,0 +1,83 @@
+package synthetic
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/keptn/go-utils/pkg/common/timeutils"
+	log "github.com/sirupsen/logrus"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+)
+
+// ExternalTestFinishedEventHandler handles a test finished event declaring the results of tests executed outside of Dynatrace Synthetic by pushing them as third-party synthetic monitors to Dynatrace.
+type ExternalTestFinishedEventHandler struct {
+	event    ExternalTestFinishedAdapterInterface
+	dtClient dynatrace.ClientInterface
+	rClient  keptn.SyntheticResultsReaderInterface
+}
+
+// NewExternalTestFinishedEventHandler creates a new ExternalTestFinishedEventHandler.
+func NewExternalTestFinishedEventHandler(event ExternalTestFinishedAdapterInterface, dtClient dynatrace.ClientInterface, rClient keptn.SyntheticResultsReaderInterface) *ExternalTestFinishedEventHandler {
+	return &ExternalTestFinishedEventHandler{
+		event:    event,
+		dtClient: dtClient,
+		rClient:  rClient,
+	}
+}
+
+// HandleEvent handles a test finished event declaring third-party test results. As the test is already finished, no event is sent to Keptn.
+func (eh *ExternalTestFinishedEventHandler) HandleEvent(workCtx context.Context, replyCtx context.Context) error {
+	results, err := eh.getTestResults()
+	if err != nil {
+		log.WithError(err).Error("Could not get third-party test results")
+		return err
+	}
+
+	tests, err := newThirdPartySyntheticTests(workCtx, eh.event, *results, eh.getTestStart(), time.Now())
+	if err != nil {
+		log.WithError(err).Error("Could not map third-party test results")
+		return err
+	}
+
+	err = dynatrace.NewThirdPartySyntheticClient(eh.dtClient).Push(workCtx, tests)
+	if err != nil {
+		log.WithError(err).Error("Could not push third-party test results")
+		return err
+	}
+
+	log.WithFields(log.Fields{"engine": tests.SyntheticEngineName, "tests": len(tests.Tests)}).Info("Pushed third-party test results")
+	return nil
+}
+
+// getTestResults gets the test results specified inline or, if a resource is declared, stored in the resource.
+func (eh *ExternalTestFinishedEventHandler) getTestResults() (*ExternalTestResults, error) {
+	declaration := eh.event.GetSyntheticResults()
+	if declaration == nil {
+		return nil, errors.New("event declares no synthetic results")
+	}
+
+	if declaration.Resource == "" {
+		return &declaration.ExternalTestResults, nil
+	}
+
+	content, err := eh.rClient.GetSyntheticResults(eh.event.GetProject(), eh.event.GetStage(), eh.event.GetService(), declaration.Resource)
+	if err != nil {
+		return nil, fmt.Errorf("could not get test results resource '%s': %w", declaration.Resource, err)
+	}
+
+	return parseExternalTestResults(*declaration, []byte(content))
+}
+
+// getTestStart gets the start of the test from the event, so that steps without own timestamps are reported at the time they were executed.
+// If the event specifies no start, the test is assumed to have started just now.
+func (eh *ExternalTestFinishedEventHandler) getTestStart() time.Time {
+	start, err := timeutils.ParseTimestamp(eh.event.GetTestDetails().Start)
+	if err != nil {
+		return time.Now()
+	}
+	return *start
+}
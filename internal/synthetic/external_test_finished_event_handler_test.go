@@ -0,0 +1,122 @@
+package synthetic
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+	"github.com/stretchr/testify/assert"
+)
+
+// syntheticResultsReaderMock returns the resources stored by URI.
+type syntheticResultsReaderMock struct {
+	resources map[string]string
+}
+
+func (m *syntheticResultsReaderMock) GetSyntheticResults(project string, stage string, service string, resourceURI string) (string, error) {
+	resource, ok := m.resources[resourceURI]
+	if !ok {
+		return "", &keptn.ResourceNotFoundError{}
+	}
+	return resource, nil
+}
+
+func createTestExternalTestFinishedAdapter(t *testing.T, data map[string]interface{}) *ExternalTestFinishedAdapter {
+	event := cloudevents.NewEvent()
+	event.SetID("finished-1")
+	event.SetType("sh.keptn.event.test.finished")
+	event.SetSource("k6-service")
+	event.SetExtension("shkeptncontext", "context-1")
+
+	data["project"] = "easytravel"
+	data["stage"] = "staging"
+	data["service"] = "frontend"
+	err := event.SetData(cloudevents.ApplicationJSON, data)
+	assert.NoError(t, err)
+
+	finishedAdapter, err := NewExternalTestFinishedAdapterFromEvent(event)
+	assert.NoError(t, err)
+	return finishedAdapter
+}
+
+func getPushedThirdPartySyntheticTests(t *testing.T, dtClient *dynatraceClientMock) []dynatrace.ThirdPartySyntheticTests {
+	pushedTests := []dynatrace.ThirdPartySyntheticTests{}
+	for _, body := range dtClient.posts["/api/v1/synthetic/ext/tests"] {
+		tests := dynatrace.ThirdPartySyntheticTests{}
+		err := json.Unmarshal(body, &tests)
+		assert.NoError(t, err)
+		pushedTests = append(pushedTests, tests)
+	}
+
+	return pushedTests
+}
+
+func TestExternalTestFinishedEventHandler_HandleEvent_Inline(t *testing.T) {
+	event := createTestExternalTestFinishedAdapter(t, map[string]interface{}{
+		"test": map[string]interface{}{"start": "2022-04-15T10:00:00.000Z", "end": "2022-04-15T10:01:00.000Z"},
+		"syntheticResults": map[string]interface{}{
+			"engine":   "Postman",
+			"location": "GitHub Actions",
+			"tests": []interface{}{
+				map[string]interface{}{
+					"name":  "checkout",
+					"steps": []interface{}{map[string]interface{}{"name": "pay", "success": true, "durationMillis": 350}},
+				},
+			},
+		},
+	})
+	dtClient := newDynatraceClientMock()
+
+	err := NewExternalTestFinishedEventHandler(event, dtClient, &syntheticResultsReaderMock{}).HandleEvent(context.TODO(), context.TODO())
+	assert.NoError(t, err)
+
+	pushedTests := getPushedThirdPartySyntheticTests(t, dtClient)
+	if assert.Len(t, pushedTests, 1) {
+		assert.Equal(t, "Postman", pushedTests[0].SyntheticEngineName)
+		assert.EqualValues(t, []dynatrace.ThirdPartySyntheticLocation{{ID: "github-actions", Name: "GitHub Actions"}}, pushedTests[0].Locations)
+		if assert.Len(t, pushedTests[0].TestResults, 1) {
+			locationResult := pushedTests[0].TestResults[0].LocationResults[0]
+			assert.True(t, locationResult.Success)
+			assert.Equal(t, time.Date(2022, 4, 15, 10, 0, 0, 0, time.UTC).UnixMilli(), locationResult.StartTimestamp)
+		}
+	}
+}
+
+func TestExternalTestFinishedEventHandler_HandleEvent_Resource(t *testing.T) {
+	event := createTestExternalTestFinishedAdapter(t, map[string]interface{}{
+		"syntheticResults": map[string]interface{}{"resource": "tests/report.xml"},
+	})
+	dtClient := newDynatraceClientMock()
+	rClient := &syntheticResultsReaderMock{
+		resources: map[string]string{
+			"tests/report.xml": `<testsuite name="health"><testcase name="status" time="0.05"><failure message="timeout"/></testcase></testsuite>`,
+		},
+	}
+
+	err := NewExternalTestFinishedEventHandler(event, dtClient, rClient).HandleEvent(context.TODO(), context.TODO())
+	assert.NoError(t, err)
+
+	pushedTests := getPushedThirdPartySyntheticTests(t, dtClient)
+	if assert.Len(t, pushedTests, 1) {
+		assert.Equal(t, "JUnit", pushedTests[0].SyntheticEngineName)
+		if assert.Len(t, pushedTests[0].Tests, 1) {
+			assert.Equal(t, "keptn-easytravel-staging-frontend-junit-health", pushedTests[0].Tests[0].ID)
+			assert.False(t, pushedTests[0].TestResults[0].LocationResults[0].Success)
+		}
+	}
+}
+
+func TestExternalTestFinishedEventHandler_HandleEvent_ResourceNotFound(t *testing.T) {
+	event := createTestExternalTestFinishedAdapter(t, map[string]interface{}{
+		"syntheticResults": map[string]interface{}{"resource": "tests/report.xml"},
+	})
+	dtClient := newDynatraceClientMock()
+
+	err := NewExternalTestFinishedEventHandler(event, dtClient, &syntheticResultsReaderMock{}).HandleEvent(context.TODO(), context.TODO())
+	assert.Error(t, err)
+	assert.Empty(t, getPushedThirdPartySyntheticTests(t, dtClient))
+}
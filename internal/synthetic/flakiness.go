@@ -0,0 +1,303 @@
+package synthetic
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+	log "github.com/sirupsen/logrus"
+)
+
+// monitorHistoryMaxLength limits the results kept per monitor and location, so that the flakiness score reflects the recent behavior
+const monitorHistoryMaxLength = 20
+
+// minFlakinessHistoryLength is the number of results required before a monitor is quarantined based on its flakiness score
+const minFlakinessHistoryLength = 5
+
+// monitorHistory is the result history of the monitors of a service in a stage, stored as Keptn resource.
+type monitorHistory struct {
+	Monitors []monitorLocationHistory `json:"monitors"`
+
+	// BatchIds are the batches of the latest tests added, so that a test resumed after a restart is not added again
+	BatchIds []string `json:"batchIds,omitempty"`
+}
+
+// monitorLocationHistory are the results of the latest executions of a monitor at a location, the oldest first.
+type monitorLocationHistory struct {
+	MonitorId  string `json:"monitorId"`
+	LocationId string `json:"locationId"`
+	Successful []bool `json:"successful"`
+}
+
+// MonitorFlakiness is the flakiness score of a monitor at a location, i.e. how often its result changed between consecutive executions.
+// A score of 0 means the result never changed, a score of 1 that it changed on every execution.
+type MonitorFlakiness struct {
+	MonitorId  string  `json:"monitorId"`
+	LocationId string  `json:"locationId"`
+	Score      float64 `json:"score"`
+	// Executions is the number of executions the score is based on
+	Executions int `json:"executions"`
+}
+
+func parseMonitorHistory(content string) (*monitorHistory, error) {
+	history := &monitorHistory{}
+	err := json.Unmarshal([]byte(content), history)
+	if err != nil {
+		return nil, err
+	}
+
+	return history, nil
+}
+
+// add adds the results of the executions to the history unless the batch has already been added. Failed executions of earlier attempts are added before the final result of a monitor and location pair,
+// so that an execution succeeding only when retried counts as flaky.
+func (h *monitorHistory) add(executionData connector.ExecutionData) bool {
+	if executionData.BatchId != "" {
+		for _, batchId := range h.BatchIds {
+			if batchId == executionData.BatchId {
+				return false
+			}
+		}
+
+		h.BatchIds = append(h.BatchIds, executionData.BatchId)
+		if len(h.BatchIds) > monitorHistoryMaxLength {
+			h.BatchIds = h.BatchIds[len(h.BatchIds)-monitorHistoryMaxLength:]
+		}
+	}
+
+	finalExecutionIds := make(map[string]bool, len(executionData.TriggeredExecutions))
+	for _, triggeredExecution := range executionData.TriggeredExecutions {
+		finalExecutionIds[triggeredExecution.ExecutionId] = true
+	}
+
+	retriedFailures := make(map[connector.MonitorLocation]int)
+	for _, attempt := range executionData.Attempts {
+		for _, failedExecution := range attempt.FailedExecutions {
+			if !finalExecutionIds[failedExecution.ExecutionId] {
+				retriedFailures[connector.MonitorLocation{MonitorId: failedExecution.MonitorId, LocationId: failedExecution.LocationId}]++
+			}
+		}
+	}
+
+	failedExecutionIds := make(map[string]bool, len(executionData.FailedExecutions))
+	for _, failedExecution := range executionData.FailedExecutions {
+		failedExecutionIds[failedExecution.ExecutionId] = true
+	}
+
+	for _, triggeredExecution := range executionData.TriggeredExecutions {
+		monitorLocation := connector.MonitorLocation{MonitorId: triggeredExecution.MonitorId, LocationId: triggeredExecution.LocationId}
+		locationHistory := h.getOrAdd(monitorLocation)
+		for i := 0; i < retriedFailures[monitorLocation]; i++ {
+			locationHistory.Successful = append(locationHistory.Successful, false)
+		}
+		locationHistory.Successful = append(locationHistory.Successful, !failedExecutionIds[triggeredExecution.ExecutionId])
+
+		if len(locationHistory.Successful) > monitorHistoryMaxLength {
+			locationHistory.Successful = locationHistory.Successful[len(locationHistory.Successful)-monitorHistoryMaxLength:]
+		}
+	}
+
+	return true
+}
+
+func (h *monitorHistory) getOrAdd(monitorLocation connector.MonitorLocation) *monitorLocationHistory {
+	for i := range h.Monitors {
+		if h.Monitors[i].MonitorId == monitorLocation.MonitorId && h.Monitors[i].LocationId == monitorLocation.LocationId {
+			return &h.Monitors[i]
+		}
+	}
+
+	h.Monitors = append(h.Monitors, monitorLocationHistory{MonitorId: monitorLocation.MonitorId, LocationId: monitorLocation.LocationId})
+	return &h.Monitors[len(h.Monitors)-1]
+}
+
+// getFlakiness gets the flakiness of the monitor and location pairs executed in the test, in the order of their executions.
+func (h *monitorHistory) getFlakiness(executionData connector.ExecutionData) []MonitorFlakiness {
+	flakiness := []MonitorFlakiness{}
+	for _, triggeredExecution := range executionData.TriggeredExecutions {
+		locationHistory := h.getOrAdd(connector.MonitorLocation{MonitorId: triggeredExecution.MonitorId, LocationId: triggeredExecution.LocationId})
+		flakiness = append(flakiness, MonitorFlakiness{
+			MonitorId:  locationHistory.MonitorId,
+			LocationId: locationHistory.LocationId,
+			Score:      getFlakinessScore(locationHistory.Successful),
+			Executions: len(locationHistory.Successful),
+		})
+	}
+
+	return flakiness
+}
+
+// getFlakinessScore gets the share of consecutive results which differ, rounded to two decimals.
+func getFlakinessScore(successful []bool) float64 {
+	if len(successful) < 2 {
+		return 0
+	}
+
+	changes := 0
+	for i := 1; i < len(successful); i++ {
+		if successful[i] != successful[i-1] {
+			changes++
+		}
+	}
+
+	return math.Round(float64(changes)/float64(len(successful)-1)*100) / 100
+}
+
+// updateMonitorHistory adds the results of the test to the history of the service in the stage, if monitors are quarantined based on their flakiness, and returns the flakiness of the executed monitors.
+func (eh *SyntheticTriggerEventHandler) updateMonitorHistory(executionData connector.ExecutionData) []MonitorFlakiness {
+	quarantine := eh.getQuarantine()
+	if quarantine == nil || quarantine.MinFlakinessScore == nil {
+		return nil
+	}
+
+	if executionData.BatchId == "" || eh.startTime.IsZero() {
+		return nil
+	}
+
+	history, err := eh.getMonitorHistory()
+	if err != nil {
+		log.WithError(err).Error("Could not get result history of synthetic monitors")
+		return nil
+	}
+
+	if !history.add(executionData) {
+		return history.getFlakiness(executionData)
+	}
+
+	// the history is not locked, so if tests of the same service in the same stage finish concurrently, the last upload wins
+	content, err := json.MarshalIndent(history, "", "  ")
+	if err == nil {
+		err = eh.rClient.UploadSyntheticHistory(eh.event.GetProject(), eh.event.GetStage(), eh.event.GetService(), content)
+	}
+	if err != nil {
+		log.WithError(err).Error("Could not upload result history of synthetic monitors")
+		return nil
+	}
+
+	return history.getFlakiness(executionData)
+}
+
+// getMonitorHistory gets the result history of the service in the stage or an empty history if there is none yet.
+func (eh *SyntheticTriggerEventHandler) getMonitorHistory() (*monitorHistory, error) {
+	content, err := eh.rClient.GetSyntheticHistory(eh.event.GetProject(), eh.event.GetStage(), eh.event.GetService())
+	if err != nil {
+		var rnfErr *keptn.ResourceNotFoundError
+		if errors.As(err, &rnfErr) {
+			return &monitorHistory{}, nil
+		}
+
+		return nil, err
+	}
+
+	history, err := parseMonitorHistory(content)
+	if err != nil {
+		return nil, fmt.Errorf("could not parse result history of synthetic monitors: %w", err)
+	}
+
+	return history, nil
+}
+
+// getQuarantinedMonitorIds gets the monitors of the test which are quarantined explicitly or, if a minimum flakiness score is configured, due to their flakiness at any location.
+func (eh *SyntheticTriggerEventHandler) getQuarantinedMonitorIds(executionData connector.ExecutionData, flakiness []MonitorFlakiness) []string {
+	quarantine := eh.getQuarantine()
+	if quarantine == nil {
+		return nil
+	}
+
+	quarantinedMonitorIds := make(map[string]bool, len(quarantine.MonitorIds))
+	for _, monitorId := range quarantine.MonitorIds {
+		quarantinedMonitorIds[monitorId] = true
+	}
+
+	if quarantine.MinFlakinessScore != nil {
+		for _, monitorFlakiness := range flakiness {
+			if monitorFlakiness.Executions >= minFlakinessHistoryLength && monitorFlakiness.Score >= *quarantine.MinFlakinessScore {
+				quarantinedMonitorIds[monitorFlakiness.MonitorId] = true
+			}
+		}
+	}
+
+	monitorIds := []string{}
+	for _, monitorId := range getTestMonitorIds(executionData) {
+		if quarantinedMonitorIds[monitorId] {
+			monitorIds = append(monitorIds, monitorId)
+		}
+	}
+
+	return monitorIds
+}
+
+// getTestMonitorIds gets the ids of all monitors of the test including the ones which could not be triggered.
+func getTestMonitorIds(executionData connector.ExecutionData) []string {
+	monitorIds := append([]string{}, executionData.MonitorIds...)
+	for _, failedTrigger := range executionData.FailedTriggers {
+		monitorIds = connector.AppendUnique(monitorIds, failedTrigger.EntityId)
+	}
+	for _, triggeredExecution := range executionData.TriggeredExecutions {
+		monitorIds = connector.AppendUnique(monitorIds, triggeredExecution.MonitorId)
+	}
+
+	return monitorIds
+}
+
+// excludeQuarantinedMonitors removes the executions and failed triggers of the quarantined monitors, so that they are not evaluated.
+// The success rate is recalculated over the remaining executions and is 100% if no executions remain.
+func excludeQuarantinedMonitors(executionData connector.ExecutionData, quarantinedMonitorIds []string) connector.ExecutionData {
+	if len(quarantinedMonitorIds) == 0 {
+		return executionData
+	}
+
+	isQuarantined := make(map[string]bool, len(quarantinedMonitorIds))
+	for _, monitorId := range quarantinedMonitorIds {
+		isQuarantined[monitorId] = true
+	}
+
+	evaluated := executionData
+	evaluated.MonitorIds = []string{}
+	for _, monitorId := range executionData.MonitorIds {
+		if !isQuarantined[monitorId] {
+			evaluated.MonitorIds = append(evaluated.MonitorIds, monitorId)
+		}
+	}
+
+	evaluated.FailedTriggers = []connector.ExecutionNotTriggered{}
+	for _, failedTrigger := range executionData.FailedTriggers {
+		if !isQuarantined[failedTrigger.EntityId] {
+			evaluated.FailedTriggers = append(evaluated.FailedTriggers, failedTrigger)
+		}
+	}
+
+	evaluated.FailedExecutions = []connector.ExecutionNotSuccessful{}
+	for _, failedExecution := range executionData.FailedExecutions {
+		if !isQuarantined[failedExecution.MonitorId] {
+			evaluated.FailedExecutions = append(evaluated.FailedExecutions, failedExecution)
+		}
+	}
+
+	evaluated.Executions = []connector.ExecutionReport{}
+	for _, report := range executionData.Executions {
+		if !isQuarantined[report.MonitorId] {
+			evaluated.Executions = append(evaluated.Executions, report)
+		}
+	}
+
+	evaluated.ExecutionIds = []string{}
+	evaluated.TriggeredExecutions = []connector.TriggeredExecution{}
+	for _, triggeredExecution := range executionData.TriggeredExecutions {
+		if !isQuarantined[triggeredExecution.MonitorId] {
+			evaluated.ExecutionIds = append(evaluated.ExecutionIds, triggeredExecution.ExecutionId)
+			evaluated.TriggeredExecutions = append(evaluated.TriggeredExecutions, triggeredExecution)
+		}
+	}
+
+	evaluated.SuccessRate = 100
+	if len(evaluated.TriggeredExecutions) > 0 {
+		successful := len(evaluated.TriggeredExecutions) - len(evaluated.FailedExecutions)
+		evaluated.SuccessRate = math.Round(float64(successful)/float64(len(evaluated.TriggeredExecutions))*10000) / 100
+	}
+
+	return evaluated
+}
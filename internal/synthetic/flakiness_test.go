@@ -0,0 +1,129 @@
+package synthetic
+
+import (
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFlakinessScore(t *testing.T) {
+	tests := []struct {
+		name       string
+		successful []bool
+		want       float64
+	}{
+		{name: "no results", want: 0},
+		{name: "single result", successful: []bool{false}, want: 0},
+		{name: "stable", successful: []bool{true, true, true}, want: 0},
+		{name: "consistently failing", successful: []bool{false, false, false}, want: 0},
+		{name: "alternating", successful: []bool{true, false, true, false}, want: 1},
+		{name: "single change", successful: []bool{true, true, true, false}, want: 0.33},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, getFlakinessScore(tt.successful))
+		})
+	}
+}
+
+func TestMonitorHistory_Add(t *testing.T) {
+	history := &monitorHistory{
+		Monitors: []monitorLocationHistory{
+			{MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1", Successful: []bool{true, true, true, true, true, true, true, true, true, true, true, true, true, true, true, true, true, true, true, false}},
+			{MonitorId: "HTTP_CHECK-3", LocationId: "GEOLOCATION-1", Successful: []bool{true}},
+		},
+	}
+
+	// execution 2 of HTTP_CHECK-2 failed and succeeded when retried as execution 3
+	history.add(connector.ExecutionData{
+		TriggeredExecutions: []connector.TriggeredExecution{
+			{ExecutionId: "1", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1"},
+			{ExecutionId: "3", MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1"},
+		},
+		Attempts: []connector.ExecutionAttempt{
+			{BatchId: "1", ExecutionIds: []string{"1", "2"}, FailedExecutions: []connector.ExecutionNotSuccessful{{ExecutionId: "2", MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1"}}},
+			{BatchId: "2", ExecutionIds: []string{"3"}},
+		},
+	})
+
+	assert.Equal(t, []monitorLocationHistory{
+		{MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1", Successful: []bool{true, true, true, true, true, true, true, true, true, true, true, true, true, true, true, true, true, true, false, true}},
+		{MonitorId: "HTTP_CHECK-3", LocationId: "GEOLOCATION-1", Successful: []bool{true}},
+		{MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1", Successful: []bool{false, true}},
+	}, history.Monitors)
+
+	assert.Equal(t, []MonitorFlakiness{
+		{MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1", Score: 0.11, Executions: 20},
+		{MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1", Score: 1, Executions: 2},
+	}, history.getFlakiness(connector.ExecutionData{
+		TriggeredExecutions: []connector.TriggeredExecution{
+			{ExecutionId: "1", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1"},
+			{ExecutionId: "3", MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1"},
+		},
+	}))
+}
+
+// TestMonitorHistory_AddSkipsAddedBatch tests that the results of a batch are only added once, e.g. if the finished event of a resumed test is sent again.
+func TestMonitorHistory_AddSkipsAddedBatch(t *testing.T) {
+	history := &monitorHistory{}
+	executionData := connector.ExecutionData{
+		BatchId: "1",
+		TriggeredExecutions: []connector.TriggeredExecution{
+			{ExecutionId: "1", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1"},
+		},
+	}
+
+	assert.True(t, history.add(executionData))
+	assert.False(t, history.add(executionData))
+
+	executionData.BatchId = "2"
+	assert.True(t, history.add(executionData))
+
+	assert.Equal(t, []string{"1", "2"}, history.BatchIds)
+	assert.Equal(t, []monitorLocationHistory{
+		{MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1", Successful: []bool{true, true}},
+	}, history.Monitors)
+}
+
+func TestExcludeQuarantinedMonitors(t *testing.T) {
+	executionData := connector.ExecutionData{
+		BatchId:      "1",
+		MonitorIds:   []string{"HTTP_CHECK-1", "HTTP_CHECK-2", "HTTP_CHECK-3"},
+		ExecutionIds: []string{"1", "2", "3"},
+		TriggeredExecutions: []connector.TriggeredExecution{
+			{ExecutionId: "1", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1"},
+			{ExecutionId: "2", MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1"},
+			{ExecutionId: "3", MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-2"},
+		},
+		FailedTriggers: []connector.ExecutionNotTriggered{{EntityId: "HTTP_CHECK-3", LocationId: "GEOLOCATION-1"}},
+		FailedExecutions: []connector.ExecutionNotSuccessful{
+			{ExecutionId: "2", MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1"},
+		},
+		SuccessRate: 66.67,
+	}
+
+	t.Run("no quarantined monitors", func(t *testing.T) {
+		assert.Equal(t, executionData, excludeQuarantinedMonitors(executionData, nil))
+	})
+
+	t.Run("quarantined monitors", func(t *testing.T) {
+		evaluated := excludeQuarantinedMonitors(executionData, []string{"HTTP_CHECK-2", "HTTP_CHECK-3"})
+		assert.Equal(t, []string{"HTTP_CHECK-1"}, evaluated.MonitorIds)
+		assert.Equal(t, []string{"1"}, evaluated.ExecutionIds)
+		assert.Empty(t, evaluated.FailedTriggers)
+		assert.Empty(t, evaluated.FailedExecutions)
+		assert.Equal(t, 100.0, evaluated.SuccessRate)
+
+		// the reported results are not changed
+		assert.Len(t, executionData.FailedExecutions, 1)
+		assert.Equal(t, 66.67, executionData.SuccessRate)
+	})
+
+	t.Run("all monitors quarantined", func(t *testing.T) {
+		evaluated := excludeQuarantinedMonitors(executionData, []string{"HTTP_CHECK-1", "HTTP_CHECK-2", "HTTP_CHECK-3"})
+		assert.Empty(t, evaluated.TriggeredExecutions)
+		assert.Equal(t, 100.0, evaluated.SuccessRate)
+	})
+}
@@ -0,0 +1,273 @@
+package synthetic
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+)
+
+// localRunnerEngineName is the engine the checks executed by the service itself are reported for
+const localRunnerEngineName = "Keptn"
+
+const localBatchIdPrefix = "local-"
+const localCheckTimeout = 30 * time.Second
+
+// localCheckRetryDelay is the time waited before a failed check is repeated
+const localCheckRetryDelay = 5 * time.Second
+
+// localCheckMaxBodySize limits the size of the response body matched against the body regex
+const localCheckMaxBodySize = 1024 * 1024
+
+const (
+	localExecutionStage          = "EXECUTED"
+	localStatusSuccess           = "SUCCESS"
+	localStatusFailed            = "FAILED"
+	localErrorRequestFailed      = "REQUEST_FAILED"
+	localErrorConstraintViolated = "CONSTRAINT_VIOLATED"
+)
+
+// localCheckResult is the result of the last attempt of an HTTP check executed by the service itself.
+type localCheckResult struct {
+	check      config.SyntheticCheckDeclaration
+	start      time.Time
+	duration   time.Duration
+	statusCode int
+
+	// errorCode and failureMessage are only set if the check failed
+	errorCode      string
+	failureMessage string
+}
+
+func (r localCheckResult) isSuccessful() bool {
+	return r.errorCode == ""
+}
+
+// runLocalChecks executes the checks one after another and stops once ctx is done, e.g. if the test is aborted.
+func runLocalChecks(ctx context.Context, httpClient *http.Client, checks []config.SyntheticCheckDeclaration, retryDelay time.Duration) []localCheckResult {
+	results := make([]localCheckResult, 0, len(checks))
+	for _, check := range checks {
+		if ctx.Err() != nil {
+			break
+		}
+
+		results = append(results, runLocalCheck(ctx, httpClient, check, retryDelay))
+	}
+
+	return results
+}
+
+// runLocalCheck executes the check and repeats it after retryDelay up to the declared number of retries as long as it fails.
+func runLocalCheck(ctx context.Context, httpClient *http.Client, check config.SyntheticCheckDeclaration, retryDelay time.Duration) localCheckResult {
+	for attempt := 1; ; attempt++ {
+		result := executeLocalCheck(ctx, httpClient, check)
+		if result.isSuccessful() {
+			return result
+		}
+
+		if attempt > check.Retries || !waitForRetry(ctx, retryDelay) {
+			if attempt > 1 {
+				result.failureMessage = fmt.Sprintf("%s (%d attempts)", result.failureMessage, attempt)
+			}
+			return result
+		}
+	}
+}
+
+// waitForRetry waits for the delay and returns false if ctx is done before.
+func waitForRetry(ctx context.Context, delay time.Duration) bool {
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return false
+	case <-timer.C:
+		return true
+	}
+}
+
+func executeLocalCheck(ctx context.Context, httpClient *http.Client, check config.SyntheticCheckDeclaration) localCheckResult {
+	result := localCheckResult{
+		check: check,
+		start: time.Now(),
+	}
+
+	failed := func(errorCode string, format string, a ...interface{}) localCheckResult {
+		result.errorCode = errorCode
+		result.failureMessage = fmt.Sprintf(format, a...)
+		return result
+	}
+
+	request, err := http.NewRequestWithContext(ctx, check.GetMethod(), check.Url, strings.NewReader(check.Body))
+	if err != nil {
+		return failed(localErrorRequestFailed, "could not create request: %v", err)
+	}
+
+	for _, header := range check.Headers {
+		request.Header.Add(header.Name, header.Value)
+	}
+
+	response, err := httpClient.Do(request)
+	if err != nil {
+		result.duration = time.Since(result.start)
+		return failed(localErrorRequestFailed, "request failed: %v", err)
+	}
+	defer response.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(response.Body, localCheckMaxBodySize))
+	result.duration = time.Since(result.start)
+	result.statusCode = response.StatusCode
+	if err != nil {
+		return failed(localErrorRequestFailed, "could not read response body: %v", err)
+	}
+
+	if check.ExpectedStatus != 0 && response.StatusCode != check.ExpectedStatus {
+		return failed(localErrorConstraintViolated, "response status code %d is not the expected %d", response.StatusCode, check.ExpectedStatus)
+	}
+
+	if check.ExpectedStatus == 0 && response.StatusCode >= 400 {
+		return failed(localErrorConstraintViolated, "response status code %d indicates an error", response.StatusCode)
+	}
+
+	if check.BodyRegex != "" {
+		bodyRegex, err := regexp.Compile(check.BodyRegex)
+		if err != nil {
+			return failed(localErrorConstraintViolated, "invalid body regex: %v", err)
+		}
+
+		if !bodyRegex.Match(body) {
+			return failed(localErrorConstraintViolated, "response body does not match %s", check.BodyRegex)
+		}
+	}
+
+	maxLatency, err := check.GetMaxLatency()
+	if err != nil {
+		return failed(localErrorConstraintViolated, "invalid max latency: %v", err)
+	}
+
+	if maxLatency > 0 && result.duration > maxLatency {
+		return failed(localErrorConstraintViolated, "response time %s exceeds %s", result.duration.Round(time.Millisecond), maxLatency)
+	}
+
+	return result
+}
+
+// newLocalExecutionData creates the execution data of the checks, using the IDs of their third-party synthetic monitors as monitor IDs,
+// so that the finished event has the same shape as for batches executed by Dynatrace.
+func newLocalExecutionData(event adapter.EventContentAdapter, location string, results []localCheckResult) connector.ExecutionData {
+	executionData := connector.ExecutionData{
+		BatchId: localBatchIdPrefix + event.GetShKeptnContext(),
+	}
+
+	locationId := getExternalLocationID(location)
+	successfulExecutions := 0
+	for _, result := range results {
+		monitorId := getExternalTestID(event, localRunnerEngineName, result.check.Name)
+		executionId := fmt.Sprintf("%s-%d", monitorId, result.start.UnixMilli())
+		durationMillis := result.duration.Milliseconds()
+
+		executionData.MonitorIds = append(executionData.MonitorIds, monitorId)
+		executionData.ExecutionIds = append(executionData.ExecutionIds, executionId)
+		executionData.TriggeredExecutions = append(executionData.TriggeredExecutions, connector.TriggeredExecution{
+			ExecutionId: executionId,
+			MonitorId:   monitorId,
+			LocationId:  locationId,
+		})
+
+		status := localStatusSuccess
+		if result.isSuccessful() {
+			successfulExecutions++
+		} else {
+			status = localStatusFailed
+			executionData.FailedExecutions = append(executionData.FailedExecutions, connector.ExecutionNotSuccessful{
+				ExecutionId:        executionId,
+				ExecutionStage:     localExecutionStage,
+				ExecutionTimestamp: int(result.start.UnixMilli() + durationMillis),
+				MonitorId:          monitorId,
+				LocationId:         locationId,
+			})
+		}
+
+		report := connector.ExecutionReport{
+			ExecutionId:        executionId,
+			MonitorId:          monitorId,
+			LocationId:         locationId,
+			ExecutionStage:     localExecutionStage,
+			ExecutionTimestamp: result.start.UnixMilli() + durationMillis,
+			SimpleResults: connector.ExecutionSimpleResults{
+				StartTimestamp:     result.start.UnixMilli(),
+				Status:             status,
+				ErrorCode:          result.errorCode,
+				FailureMessage:     result.failureMessage,
+				ResponseStatusCode: result.statusCode,
+				Duration:           durationMillis,
+			},
+			FullResults: connector.ExecutionFullResults{
+				ExecutionSteps: []connector.ExecutionStepResult{
+					{
+						RequestId:          "1",
+						RequestName:        result.check.GetMethod() + " " + result.check.Url,
+						StartTimestamp:     result.start.UnixMilli(),
+						Status:             status,
+						ResponseStatusCode: result.statusCode,
+						ResponseTime:       durationMillis,
+						ErrorCode:          result.errorCode,
+						FailureMessage:     result.failureMessage,
+					},
+				},
+			},
+		}
+
+		if !result.isSuccessful() {
+			report.SimpleResults.FailedStepName = report.FullResults.ExecutionSteps[0].RequestName
+			report.SimpleResults.FailedStepSequenceId = 1
+		}
+
+		executionData.Executions = append(executionData.Executions, report)
+	}
+
+	if len(results) > 0 {
+		executionData.SuccessRate = math.Round(float64(successfulExecutions)/float64(len(results))*10000) / 100
+	}
+
+	return executionData
+}
+
+// newLocalExternalTestResults creates the results of the checks reported as third-party synthetic monitors, each check as a monitor with a single step.
+func newLocalExternalTestResults(location string, results []localCheckResult) ExternalTestResults {
+	externalResults := ExternalTestResults{
+		Engine:   localRunnerEngineName,
+		Location: location,
+	}
+
+	for _, result := range results {
+		step := ExternalTestStep{
+			Name:           result.check.GetMethod() + " " + result.check.Url,
+			Success:        result.isSuccessful(),
+			StartTimestamp: result.start.UnixMilli(),
+			DurationMillis: result.duration.Milliseconds(),
+			ErrorMessage:   result.failureMessage,
+		}
+
+		// the status code is reported as error code of failed checks, successful checks have no error
+		if !step.Success {
+			step.ErrorCode = result.statusCode
+		}
+
+		externalResults.Tests = append(externalResults.Tests, ExternalTest{
+			Name:  result.check.Name,
+			Steps: []ExternalTestStep{step},
+		})
+	}
+
+	return externalResults
+}
@@ -0,0 +1,189 @@
+package synthetic
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunLocalCheck(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/health":
+			if r.Header.Get("Authorization") != "Bearer secret" {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			w.Write([]byte(`{"status": "UP"}`))
+		case "/flaky":
+			attempts++
+			if attempts < 3 {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			w.Write([]byte("ok"))
+		case "/slow":
+			time.Sleep(50 * time.Millisecond)
+			w.Write([]byte("ok"))
+		case "/created":
+			assert.Equal(t, http.MethodPost, r.Method)
+			w.WriteHeader(http.StatusCreated)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name               string
+		check              config.SyntheticCheckDeclaration
+		wantErrorCode      string
+		wantFailureMessage string
+		wantStatusCode     int
+	}{
+		{
+			name: "successful check",
+			check: config.SyntheticCheckDeclaration{
+				Name:           "health",
+				Url:            server.URL + "/health",
+				Headers:        []config.SyntheticRequestHeader{{Name: "Authorization", Value: "Bearer secret"}},
+				ExpectedStatus: 200,
+				BodyRegex:      `"status":\s*"UP"`,
+				MaxLatency:     "5s",
+			},
+			wantStatusCode: 200,
+		},
+		{
+			name:               "error status",
+			check:              config.SyntheticCheckDeclaration{Name: "missing", Url: server.URL + "/missing"},
+			wantErrorCode:      "CONSTRAINT_VIOLATED",
+			wantFailureMessage: "response status code 404 indicates an error",
+			wantStatusCode:     404,
+		},
+		{
+			name:               "unexpected status",
+			check:              config.SyntheticCheckDeclaration{Name: "create", Method: "post", Url: server.URL + "/created", ExpectedStatus: 200},
+			wantErrorCode:      "CONSTRAINT_VIOLATED",
+			wantFailureMessage: "response status code 201 is not the expected 200",
+			wantStatusCode:     201,
+		},
+		{
+			name:               "body mismatch",
+			check:              config.SyntheticCheckDeclaration{Name: "health", Url: server.URL + "/health", Headers: []config.SyntheticRequestHeader{{Name: "Authorization", Value: "Bearer secret"}}, BodyRegex: "DOWN"},
+			wantErrorCode:      "CONSTRAINT_VIOLATED",
+			wantFailureMessage: "response body does not match DOWN",
+			wantStatusCode:     200,
+		},
+		{
+			name:           "successful retry",
+			check:          config.SyntheticCheckDeclaration{Name: "flaky", Url: server.URL + "/flaky", Retries: 2},
+			wantStatusCode: 200,
+		},
+		{
+			name:               "failed retries",
+			check:              config.SyntheticCheckDeclaration{Name: "missing", Url: server.URL + "/missing", Retries: 1},
+			wantErrorCode:      "CONSTRAINT_VIOLATED",
+			wantFailureMessage: "response status code 404 indicates an error (2 attempts)",
+			wantStatusCode:     404,
+		},
+		{
+			name:           "latency exceeded",
+			check:          config.SyntheticCheckDeclaration{Name: "slow", Url: server.URL + "/slow", MaxLatency: "10ms"},
+			wantErrorCode:  "CONSTRAINT_VIOLATED",
+			wantStatusCode: 200,
+		},
+		{
+			name:          "request failed",
+			check:         config.SyntheticCheckDeclaration{Name: "unreachable", Url: "http://localhost:0/health"},
+			wantErrorCode: "REQUEST_FAILED",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := runLocalCheck(context.TODO(), server.Client(), tt.check, time.Millisecond)
+			assert.Equal(t, tt.wantErrorCode, result.errorCode)
+			assert.Equal(t, tt.wantStatusCode, result.statusCode)
+			if tt.wantFailureMessage != "" {
+				assert.Equal(t, tt.wantFailureMessage, result.failureMessage)
+			}
+		})
+	}
+}
+
+func TestRunLocalCheck_StopsRetryingOnceContextIsDone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	result := runLocalCheck(ctx, server.Client(), config.SyntheticCheckDeclaration{Name: "unavailable", Url: server.URL, Retries: 3}, time.Hour)
+	assert.Less(t, time.Since(start), time.Minute)
+	assert.Equal(t, "CONSTRAINT_VIOLATED", result.errorCode)
+	assert.Equal(t, "response status code 503 indicates an error", result.failureMessage)
+}
+
+func TestNewLocalExecutionData(t *testing.T) {
+	event := createTestSyntheticTriggerAdapter(t, map[string]interface{}{})
+	start := time.Date(2022, 4, 15, 10, 0, 0, 0, time.UTC)
+
+	results := []localCheckResult{
+		{check: config.SyntheticCheckDeclaration{Name: "health", Url: "http://frontend/health"}, start: start, duration: 100 * time.Millisecond, statusCode: 200},
+		{check: config.SyntheticCheckDeclaration{Name: "login", Method: "post", Url: "http://frontend/login"}, start: start.Add(time.Second), duration: 200 * time.Millisecond, statusCode: 500, errorCode: "CONSTRAINT_VIOLATED", failureMessage: "response status code 500 indicates an error"},
+	}
+
+	executionData := newLocalExecutionData(event, "", results)
+
+	healthId := "keptn-easytravel-staging-frontend-keptn-health"
+	loginId := "keptn-easytravel-staging-frontend-keptn-login"
+	loginExecutionId := loginId + "-1650016801000"
+	assert.Equal(t, "local-context-1", executionData.BatchId)
+	assert.Equal(t, []string{healthId, loginId}, executionData.MonitorIds)
+	assert.Equal(t, []string{healthId + "-1650016800000", loginExecutionId}, executionData.ExecutionIds)
+	assert.Equal(t, []connector.ExecutionNotSuccessful{
+		{ExecutionId: loginExecutionId, ExecutionStage: "EXECUTED", ExecutionTimestamp: 1650016801200, MonitorId: loginId, LocationId: "keptn"},
+	}, executionData.FailedExecutions)
+	assert.Equal(t, 50.0, executionData.SuccessRate)
+
+	if assert.Len(t, executionData.Executions, 2) {
+		assert.Equal(t, connector.ExecutionSimpleResults{
+			StartTimestamp:       1650016801000,
+			Status:               "FAILED",
+			ErrorCode:            "CONSTRAINT_VIOLATED",
+			FailureMessage:       "response status code 500 indicates an error",
+			FailedStepName:       "POST http://frontend/login",
+			FailedStepSequenceId: 1,
+			ResponseStatusCode:   500,
+			Duration:             200,
+		}, executionData.Executions[1].SimpleResults)
+	}
+}
+
+func TestNewLocalExternalTestResults(t *testing.T) {
+	start := time.Date(2022, 4, 15, 10, 0, 0, 0, time.UTC)
+
+	results := []localCheckResult{
+		{check: config.SyntheticCheckDeclaration{Name: "health", Url: "http://frontend/health"}, start: start, duration: 100 * time.Millisecond, statusCode: 200},
+		{check: config.SyntheticCheckDeclaration{Name: "login", Method: "post", Url: "http://frontend/login"}, start: start.Add(time.Second), duration: 200 * time.Millisecond, statusCode: 500, errorCode: "CONSTRAINT_VIOLATED", failureMessage: "response status code 500 indicates an error"},
+	}
+
+	assert.Equal(t, ExternalTestResults{
+		Engine:   localRunnerEngineName,
+		Location: "CI",
+		Tests: []ExternalTest{
+			{Name: "health", Steps: []ExternalTestStep{{Name: "GET http://frontend/health", Success: true, StartTimestamp: 1650016800000, DurationMillis: 100}}},
+			{Name: "login", Steps: []ExternalTestStep{{Name: "POST http://frontend/login", StartTimestamp: 1650016801000, DurationMillis: 200, ErrorCode: 500, ErrorMessage: "response status code 500 indicates an error"}}},
+		},
+	}, newLocalExternalTestResults("CI", results))
+}
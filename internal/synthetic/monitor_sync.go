@@ -0,0 +1,201 @@
+package synthetic
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	log "github.com/sirupsen/logrus"
+)
+
+// keptnManagedTag marks monitors created from the synthetic.yaml, only these are updated or pruned
+const keptnManagedTag = "keptn_managed"
+
+const httpMonitorType = "HTTP"
+const httpMonitorScriptVersion = "1.0"
+const defaultRequestMethod = "GET"
+
+// monitorUpdate is an existing monitor to be replaced by its declaration
+type monitorUpdate struct {
+	monitorId   string
+	declaration config.SyntheticMonitorDeclaration
+}
+
+// monitorSyncPlan lists the monitors to be created, updated and deleted to match the declared monitors
+type monitorSyncPlan struct {
+	creates []config.SyntheticMonitorDeclaration
+	updates []monitorUpdate
+	deletes []dynatrace.SyntheticMonitorSummary
+}
+
+// planMonitorSync matches the declared monitors to the existing managed monitors by name.
+// Existing monitors which are not declared are only deleted if prune is enabled.
+func planMonitorSync(declarations []config.SyntheticMonitorDeclaration, existingMonitors []dynatrace.SyntheticMonitorSummary, prune bool) monitorSyncPlan {
+	existingMonitorIdsByName := make(map[string]string, len(existingMonitors))
+	for _, monitor := range existingMonitors {
+		if _, found := existingMonitorIdsByName[monitor.Name]; !found {
+			existingMonitorIdsByName[monitor.Name] = monitor.EntityID
+		}
+	}
+
+	plan := monitorSyncPlan{}
+	declaredMonitorIds := make(map[string]bool, len(declarations))
+	for _, declaration := range declarations {
+		monitorId, found := existingMonitorIdsByName[declaration.Name]
+		if !found {
+			plan.creates = append(plan.creates, declaration)
+			continue
+		}
+
+		plan.updates = append(plan.updates, monitorUpdate{monitorId: monitorId, declaration: declaration})
+		declaredMonitorIds[monitorId] = true
+	}
+
+	if !prune {
+		return plan
+	}
+
+	for _, monitor := range existingMonitors {
+		if !declaredMonitorIds[monitor.EntityID] {
+			plan.deletes = append(plan.deletes, monitor)
+		}
+	}
+
+	return plan
+}
+
+// getKeptnTags returns the tags identifying the project, stage and service of a monitor
+func getKeptnTags(project string, stage string, service string) []string {
+	return []string{
+		"keptn_project:" + project,
+		"keptn_stage:" + stage,
+		"keptn_service:" + service,
+	}
+}
+
+// getManagedMonitorTags returns the tags identifying the monitors managed for the project, stage and service
+func getManagedMonitorTags(project string, stage string, service string) []string {
+	return append([]string{keptnManagedTag}, getKeptnTags(project, stage, service)...)
+}
+
+// newSyntheticMonitorTag converts a tag specified as "key" or "key:value"
+func newSyntheticMonitorTag(tag string) dynatrace.SyntheticMonitorTag {
+	keyAndValue := strings.SplitN(tag, ":", 2)
+	if len(keyAndValue) == 1 {
+		return dynatrace.SyntheticMonitorTag{Key: keyAndValue[0]}
+	}
+
+	return dynatrace.SyntheticMonitorTag{Key: keyAndValue[0], Value: keyAndValue[1]}
+}
+
+// newHTTPMonitorDefinition converts the monitor declaration into an HTTP monitor definition having the specified tags in addition to the declared ones
+func newHTTPMonitorDefinition(declaration config.SyntheticMonitorDeclaration, tags []string) dynatrace.HTTPMonitorDefinition {
+	requests := make([]dynatrace.HTTPMonitorRequest, 0, len(declaration.Requests))
+	for _, requestDeclaration := range declaration.Requests {
+		request := dynatrace.HTTPMonitorRequest{
+			Description: requestDeclaration.Description,
+			URL:         requestDeclaration.Url,
+			Method:      strings.ToUpper(requestDeclaration.Method),
+			RequestBody: requestDeclaration.Body,
+			Configuration: dynatrace.HTTPMonitorRequestConfiguration{
+				FollowRedirects: true,
+			},
+		}
+
+		if request.Method == "" {
+			request.Method = defaultRequestMethod
+		}
+
+		for _, header := range requestDeclaration.Headers {
+			request.Configuration.RequestHeaders = append(request.Configuration.RequestHeaders, dynatrace.HTTPMonitorRequestHeader{Name: header.Name, Value: header.Value})
+		}
+
+		if len(requestDeclaration.Assertions) > 0 {
+			request.Validation = &dynatrace.HTTPMonitorRequestValidation{RulesChaining: "and"}
+			for _, assertion := range requestDeclaration.Assertions {
+				request.Validation.Rules = append(request.Validation.Rules, dynatrace.HTTPMonitorValidationRule{
+					Type:        assertion.Type,
+					Value:       assertion.Value,
+					PassIfFound: assertion.PassIfFound,
+				})
+			}
+		}
+
+		requests = append(requests, request)
+	}
+
+	monitorTags := make([]dynatrace.SyntheticMonitorTag, 0, len(tags)+len(declaration.Tags))
+	for _, tag := range tags {
+		monitorTags = append(monitorTags, newSyntheticMonitorTag(tag))
+	}
+
+	for _, tag := range declaration.Tags {
+		monitorTags = append(monitorTags, newSyntheticMonitorTag(tag))
+	}
+
+	return dynatrace.HTTPMonitorDefinition{
+		Name:         declaration.Name,
+		FrequencyMin: declaration.GetFrequency(),
+		Enabled:      true,
+		Type:         httpMonitorType,
+		Script: dynatrace.HTTPMonitorScript{
+			Version:  httpMonitorScriptVersion,
+			Requests: requests,
+		},
+		Locations: declaration.Locations,
+		AnomalyDetection: dynatrace.SyntheticAnomalyDetection{
+			OutageHandling: dynatrace.SyntheticOutageHandling{
+				GlobalOutage:      true,
+				LocalOutagePolicy: dynatrace.SyntheticLocalOutagePolicy{AffectedLocations: 1, ConsecutiveRuns: 1},
+			},
+			LoadingTimeThresholds: dynatrace.SyntheticLoadingTimeThresholds{Thresholds: []interface{}{}},
+		},
+		Tags: monitorTags,
+	}
+}
+
+// syncSyntheticMonitors creates or updates the declared monitors and, if enabled, deletes managed monitors which are no longer declared.
+// It returns the ids of the declared monitors.
+func syncSyntheticMonitors(workCtx context.Context, monitorsClient *dynatrace.SyntheticMonitorsClient, monitorsConfig *config.SyntheticMonitorsConfig, project string, stage string, service string) ([]string, error) {
+	managedMonitorTags := getManagedMonitorTags(project, stage, service)
+	existingMonitors, err := monitorsClient.GetByTags(workCtx, managedMonitorTags)
+	if err != nil {
+		return nil, fmt.Errorf("could not retrieve synthetic monitors managed by Keptn: %w", err)
+	}
+
+	plan := planMonitorSync(monitorsConfig.Monitors, existingMonitors, monitorsConfig.Prune)
+
+	monitorIds := []string{}
+	for _, declaration := range plan.creates {
+		monitorId, err := monitorsClient.CreateHTTPMonitor(workCtx, newHTTPMonitorDefinition(declaration, managedMonitorTags))
+		if err != nil {
+			return nil, err
+		}
+
+		log.WithField("monitorId", monitorId).Infof("Created synthetic monitor '%s'", declaration.Name)
+		monitorIds = append(monitorIds, monitorId)
+	}
+
+	for _, update := range plan.updates {
+		err := monitorsClient.UpdateHTTPMonitor(workCtx, update.monitorId, newHTTPMonitorDefinition(update.declaration, managedMonitorTags))
+		if err != nil {
+			return nil, err
+		}
+
+		log.WithField("monitorId", update.monitorId).Infof("Updated synthetic monitor '%s'", update.declaration.Name)
+		monitorIds = append(monitorIds, update.monitorId)
+	}
+
+	for _, monitor := range plan.deletes {
+		err := monitorsClient.DeleteByID(workCtx, monitor.EntityID)
+		if err != nil {
+			return nil, err
+		}
+
+		log.WithField("monitorId", monitor.EntityID).Infof("Deleted synthetic monitor '%s' which is no longer declared", monitor.Name)
+	}
+
+	return monitorIds, nil
+}
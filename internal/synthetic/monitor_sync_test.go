@@ -0,0 +1,120 @@
+package synthetic
+
+import (
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanMonitorSync(t *testing.T) {
+	healthCheck := config.SyntheticMonitorDeclaration{Name: "health check"}
+	login := config.SyntheticMonitorDeclaration{Name: "login"}
+
+	existingMonitors := []dynatrace.SyntheticMonitorSummary{
+		{EntityID: "HTTP_CHECK-1", Name: "health check"},
+		{EntityID: "HTTP_CHECK-2", Name: "checkout"},
+	}
+
+	tests := []struct {
+		name     string
+		prune    bool
+		wantPlan monitorSyncPlan
+	}{
+		{
+			name: "undeclared monitors are kept",
+			wantPlan: monitorSyncPlan{
+				creates: []config.SyntheticMonitorDeclaration{login},
+				updates: []monitorUpdate{{monitorId: "HTTP_CHECK-1", declaration: healthCheck}},
+			},
+		},
+		{
+			name:  "undeclared monitors are pruned",
+			prune: true,
+			wantPlan: monitorSyncPlan{
+				creates: []config.SyntheticMonitorDeclaration{login},
+				updates: []monitorUpdate{{monitorId: "HTTP_CHECK-1", declaration: healthCheck}},
+				deletes: []dynatrace.SyntheticMonitorSummary{{EntityID: "HTTP_CHECK-2", Name: "checkout"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			plan := planMonitorSync([]config.SyntheticMonitorDeclaration{healthCheck, login}, existingMonitors, tt.prune)
+			assert.Equal(t, tt.wantPlan, plan)
+		})
+	}
+}
+
+func TestNewHTTPMonitorDefinition(t *testing.T) {
+	frequency := 0
+	declaration := config.SyntheticMonitorDeclaration{
+		Name:      "health check",
+		Frequency: &frequency,
+		Locations: []string{"GEOLOCATION-1"},
+		Tags:      []string{"app:easytravel", "smoke"},
+		Requests: []config.SyntheticRequestDeclaration{
+			{
+				Description: "health",
+				Url:         "https://easytravel.example.com/health",
+				Headers:     []config.SyntheticRequestHeader{{Name: "Authorization", Value: "Bearer token"}},
+				Assertions:  []config.SyntheticAssertion{{Type: "httpStatusesList", Value: ">=400"}},
+			},
+			{
+				Url:    "https://easytravel.example.com/login",
+				Method: "post",
+				Body:   `{"user":"test"}`,
+			},
+		},
+	}
+
+	definition := newHTTPMonitorDefinition(declaration, getManagedMonitorTags("easytravel", "staging", "frontend"))
+
+	assert.Equal(t, dynatrace.HTTPMonitorDefinition{
+		Name:         "health check",
+		FrequencyMin: 0,
+		Enabled:      true,
+		Type:         "HTTP",
+		Script: dynatrace.HTTPMonitorScript{
+			Version: "1.0",
+			Requests: []dynatrace.HTTPMonitorRequest{
+				{
+					Description: "health",
+					URL:         "https://easytravel.example.com/health",
+					Method:      "GET",
+					Configuration: dynatrace.HTTPMonitorRequestConfiguration{
+						FollowRedirects: true,
+						RequestHeaders:  []dynatrace.HTTPMonitorRequestHeader{{Name: "Authorization", Value: "Bearer token"}},
+					},
+					Validation: &dynatrace.HTTPMonitorRequestValidation{
+						Rules:         []dynatrace.HTTPMonitorValidationRule{{Type: "httpStatusesList", Value: ">=400"}},
+						RulesChaining: "and",
+					},
+				},
+				{
+					URL:           "https://easytravel.example.com/login",
+					Method:        "POST",
+					RequestBody:   `{"user":"test"}`,
+					Configuration: dynatrace.HTTPMonitorRequestConfiguration{FollowRedirects: true},
+				},
+			},
+		},
+		Locations: []string{"GEOLOCATION-1"},
+		AnomalyDetection: dynatrace.SyntheticAnomalyDetection{
+			OutageHandling: dynatrace.SyntheticOutageHandling{
+				GlobalOutage:      true,
+				LocalOutagePolicy: dynatrace.SyntheticLocalOutagePolicy{AffectedLocations: 1, ConsecutiveRuns: 1},
+			},
+			LoadingTimeThresholds: dynatrace.SyntheticLoadingTimeThresholds{Thresholds: []interface{}{}},
+		},
+		Tags: []dynatrace.SyntheticMonitorTag{
+			{Key: "keptn_managed"},
+			{Key: "keptn_project", Value: "easytravel"},
+			{Key: "keptn_stage", Value: "staging"},
+			{Key: "keptn_service", Value: "frontend"},
+			{Key: "app", Value: "easytravel"},
+			{Key: "smoke"},
+		},
+	}, definition)
+}
@@ -0,0 +1,66 @@
+package synthetic
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+)
+
+// newPollingPolicy creates a connector.PollingPolicy based on the defaults, overridden by the specified wait configurations in order.
+func newPollingPolicy(waitConfigs ...config.SyntheticWaitConfig) (connector.PollingPolicy, error) {
+	policy := connector.NewDefaultPollingPolicy()
+	isMaxIntervalSet := false
+
+	for _, waitConfig := range waitConfigs {
+		var err error
+
+		if waitConfig.WaitTimeout != "" {
+			policy.Timeout, err = parseWaitDuration("waitTimeout", waitConfig.WaitTimeout)
+			if err != nil {
+				return connector.PollingPolicy{}, err
+			}
+		}
+
+		if waitConfig.WaitInterval != "" {
+			policy.InitialInterval, err = parseWaitDuration("waitInterval", waitConfig.WaitInterval)
+			if err != nil {
+				return connector.PollingPolicy{}, err
+			}
+		}
+
+		if waitConfig.WaitBackoffFactor != 0 {
+			policy.BackoffFactor = waitConfig.WaitBackoffFactor
+		}
+
+		if waitConfig.WaitMaxInterval != "" {
+			policy.MaxInterval, err = parseWaitDuration("waitMaxInterval", waitConfig.WaitMaxInterval)
+			if err != nil {
+				return connector.PollingPolicy{}, err
+			}
+			isMaxIntervalSet = true
+		}
+	}
+
+	// an interval larger than the default max interval implies a larger max interval if none is set explicitly
+	if !isMaxIntervalSet && policy.MaxInterval < policy.InitialInterval {
+		policy.MaxInterval = policy.InitialInterval
+	}
+
+	err := policy.Validate()
+	if err != nil {
+		return connector.PollingPolicy{}, err
+	}
+
+	return policy, nil
+}
+
+func parseWaitDuration(name string, value string) (time.Duration, error) {
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("could not parse %s '%s': %w", name, value, err)
+	}
+
+	return duration, nil
+}
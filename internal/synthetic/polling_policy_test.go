@@ -0,0 +1,75 @@
+package synthetic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewPollingPolicy(t *testing.T) {
+	tests := []struct {
+		name        string
+		waitConfigs []config.SyntheticWaitConfig
+		want        connector.PollingPolicy
+		wantErr     bool
+	}{
+		{
+			name: "defaults",
+			want: connector.NewDefaultPollingPolicy(),
+		},
+		{
+			name: "event overrides config",
+			waitConfigs: []config.SyntheticWaitConfig{
+				{WaitTimeout: "10m", WaitInterval: "20s", WaitBackoffFactor: 1.5, WaitMaxInterval: "2m"},
+				{WaitTimeout: "15m"},
+			},
+			want: connector.PollingPolicy{
+				Timeout:         15 * time.Minute,
+				InitialInterval: 20 * time.Second,
+				BackoffFactor:   1.5,
+				MaxInterval:     2 * time.Minute,
+			},
+		},
+		{
+			name: "interval larger than default max interval",
+			waitConfigs: []config.SyntheticWaitConfig{
+				{WaitInterval: "2m"},
+			},
+			want: connector.PollingPolicy{
+				Timeout:         connector.DefaultPollingTimeout,
+				InitialInterval: 2 * time.Minute,
+				BackoffFactor:   connector.DefaultPollingBackoffFactor,
+				MaxInterval:     2 * time.Minute,
+			},
+		},
+		{
+			name: "explicit max interval smaller than interval",
+			waitConfigs: []config.SyntheticWaitConfig{
+				{WaitInterval: "2m", WaitMaxInterval: "1m"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid duration",
+			waitConfigs: []config.SyntheticWaitConfig{
+				{WaitTimeout: "ten minutes"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := newPollingPolicy(tt.waitConfigs...)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,338 @@
+package synthetic
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	log "github.com/sirupsen/logrus"
+)
+
+const syntheticReportsDirectory = "synthetic/reports/"
+
+// SyntheticReports are the URIs of the Keptn resources the reports of a synthetic test were uploaded to, on the stage and service of the test.
+type SyntheticReports struct {
+	JUnit string `json:"junit,omitempty"`
+	JSON  string `json:"json,omitempty"`
+}
+
+// getSyntheticReportURIs gets the URIs of the JUnit XML and JSON reports of the test of the Keptn context.
+func getSyntheticReportURIs(keptnContext string) (string, string) {
+	return syntheticReportsDirectory + keptnContext + ".xml", syntheticReportsDirectory + keptnContext + ".json"
+}
+
+// uploadReports uploads the JUnit XML and JSON reports of the test to the service and stage of the test, if its execution was waited for, and returns the URIs of the uploaded reports.
+func (eh *SyntheticTriggerEventHandler) uploadReports(executionData connector.ExecutionData, timeframe *SyntheticTestTimeframe, result keptnv2.ResultType, err error) *SyntheticReports {
+	if executionData.BatchId == "" || eh.startTime.IsZero() {
+		return nil
+	}
+
+	message := ""
+	if err != nil {
+		message = err.Error()
+	}
+
+	report := newSyntheticReport(eh.event, executionData, timeframe, result, message)
+	junitURI, jsonURI := getSyntheticReportURIs(eh.event.GetShKeptnContext())
+	reports := &SyntheticReports{}
+
+	junitReport, err := report.toJUnit()
+	if err == nil {
+		err = eh.uploadReport(junitURI, junitReport)
+	}
+	if err != nil {
+		log.WithError(err).WithField("resourceURI", junitURI).Error("Could not upload JUnit report of synthetic test")
+	} else {
+		reports.JUnit = junitURI
+	}
+
+	jsonReport, err := report.toJSON()
+	if err == nil {
+		err = eh.uploadReport(jsonURI, jsonReport)
+	}
+	if err != nil {
+		log.WithError(err).WithField("resourceURI", jsonURI).Error("Could not upload JSON report of synthetic test")
+	} else {
+		reports.JSON = jsonURI
+	}
+
+	if reports.JUnit == "" && reports.JSON == "" {
+		return nil
+	}
+
+	return reports
+}
+
+func (eh *SyntheticTriggerEventHandler) uploadReport(resourceURI string, report []byte) error {
+	return eh.rClient.UploadSyntheticReport(eh.event.GetProject(), eh.event.GetStage(), eh.event.GetService(), resourceURI, report)
+}
+
+// syntheticReport is the report of a synthetic test, with the result of each execution of a monitor at a location and its steps.
+type syntheticReport struct {
+	KeptnContext   string                            `json:"keptnContext"`
+	Project        string                            `json:"project"`
+	Stage          string                            `json:"stage"`
+	Service        string                            `json:"service"`
+	BatchId        string                            `json:"batchId"`
+	Result         keptnv2.ResultType                `json:"result"`
+	Message        string                            `json:"message,omitempty"`
+	SuccessRate    float64                           `json:"successRate"`
+	Test           *SyntheticTestTimeframe           `json:"test,omitempty"`
+	Executions     []syntheticReportExecution        `json:"executions"`
+	FailedTriggers []connector.ExecutionNotTriggered `json:"failedTriggers,omitempty"`
+}
+
+// syntheticReportExecution is the result of an execution of a monitor at a location.
+type syntheticReportExecution struct {
+	ExecutionId    string                `json:"executionId"`
+	MonitorId      string                `json:"monitorId"`
+	LocationId     string                `json:"locationId"`
+	Successful     bool                  `json:"successful"`
+	Status         string                `json:"status,omitempty"`
+	ErrorCode      string                `json:"errorCode,omitempty"`
+	FailureMessage string                `json:"failureMessage,omitempty"`
+	StartTimestamp int64                 `json:"startTimestamp,omitempty"`
+	Duration       int64                 `json:"duration"`
+	Steps          []syntheticReportStep `json:"steps"`
+}
+
+// syntheticReportStep is the result of a step (browser monitors) or request (HTTP monitors) of an execution.
+type syntheticReportStep struct {
+	Name               string `json:"name"`
+	Successful         bool   `json:"successful"`
+	Status             string `json:"status"`
+	ResponseStatusCode int    `json:"responseStatusCode,omitempty"`
+	ResponseTime       int64  `json:"responseTime"`
+	ErrorCode          string `json:"errorCode,omitempty"`
+	FailureMessage     string `json:"failureMessage,omitempty"`
+}
+
+// newSyntheticReport creates the report of the test based on its triggered executions, their failures and execution reports.
+func newSyntheticReport(event adapter.EventContentAdapter, executionData connector.ExecutionData, timeframe *SyntheticTestTimeframe, result keptnv2.ResultType, message string) syntheticReport {
+	failedExecutionIds := make(map[string]bool, len(executionData.FailedExecutions))
+	for _, failedExecution := range executionData.FailedExecutions {
+		failedExecutionIds[failedExecution.ExecutionId] = true
+	}
+
+	reportsByExecutionId := make(map[string]connector.ExecutionReport, len(executionData.Executions))
+	for _, report := range executionData.Executions {
+		reportsByExecutionId[report.ExecutionId] = report
+	}
+
+	report := syntheticReport{
+		KeptnContext:   event.GetShKeptnContext(),
+		Project:        event.GetProject(),
+		Stage:          event.GetStage(),
+		Service:        event.GetService(),
+		BatchId:        executionData.BatchId,
+		Result:         result,
+		Message:        message,
+		SuccessRate:    executionData.SuccessRate,
+		Test:           timeframe,
+		Executions:     make([]syntheticReportExecution, 0, len(executionData.TriggeredExecutions)),
+		FailedTriggers: executionData.FailedTriggers,
+	}
+
+	for _, triggeredExecution := range executionData.TriggeredExecutions {
+		execution := syntheticReportExecution{
+			ExecutionId: triggeredExecution.ExecutionId,
+			MonitorId:   triggeredExecution.MonitorId,
+			LocationId:  triggeredExecution.LocationId,
+			Successful:  !failedExecutionIds[triggeredExecution.ExecutionId],
+			Steps:       []syntheticReportStep{},
+		}
+
+		if executionReport, found := reportsByExecutionId[triggeredExecution.ExecutionId]; found {
+			execution.Status = executionReport.SimpleResults.Status
+			execution.ErrorCode = executionReport.SimpleResults.ErrorCode
+			execution.FailureMessage = executionReport.SimpleResults.FailureMessage
+			execution.StartTimestamp = executionReport.SimpleResults.StartTimestamp
+			execution.Duration = executionReport.SimpleResults.Duration
+
+			for _, step := range executionReport.FullResults.ExecutionSteps {
+				execution.Steps = append(execution.Steps, newSyntheticReportStep(step))
+			}
+		}
+
+		report.Executions = append(report.Executions, execution)
+	}
+
+	return report
+}
+
+func newSyntheticReportStep(step connector.ExecutionStepResult) syntheticReportStep {
+	name := step.StepName
+	if name == "" {
+		name = step.RequestName
+	}
+
+	return syntheticReportStep{
+		Name:               name,
+		Successful:         step.Status == connector.SuccessfulStepStatus,
+		Status:             step.Status,
+		ResponseStatusCode: step.ResponseStatusCode,
+		ResponseTime:       step.ResponseTime,
+		ErrorCode:          step.ErrorCode,
+		FailureMessage:     step.FailureMessage,
+	}
+}
+
+// toJSON renders the report as indented JSON.
+func (r syntheticReport) toJSON() ([]byte, error) {
+	return json.MarshalIndent(r, "", "  ")
+}
+
+type junitReportTestSuites struct {
+	XMLName  xml.Name               `xml:"testsuites"`
+	Name     string                 `xml:"name,attr"`
+	Tests    int                    `xml:"tests,attr"`
+	Failures int                    `xml:"failures,attr"`
+	Errors   int                    `xml:"errors,attr"`
+	Suites   []junitReportTestSuite `xml:"testsuite"`
+}
+
+type junitReportTestSuite struct {
+	Name       string                `xml:"name,attr"`
+	Tests      int                   `xml:"tests,attr"`
+	Failures   int                   `xml:"failures,attr"`
+	Errors     int                   `xml:"errors,attr"`
+	Time       string                `xml:"time,attr"`
+	Properties []junitReportProperty `xml:"properties>property,omitempty"`
+	Cases      []junitReportTestCase `xml:"testcase"`
+}
+
+type junitReportProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitReportTestCase struct {
+	Name      string              `xml:"name,attr"`
+	ClassName string              `xml:"classname,attr"`
+	Time      string              `xml:"time,attr"`
+	Failure   *junitReportProblem `xml:"failure,omitempty"`
+	Error     *junitReportProblem `xml:"error,omitempty"`
+}
+
+type junitReportProblem struct {
+	Message string `xml:"message,attr"`
+	Type    string `xml:"type,attr,omitempty"`
+}
+
+// toJUnit renders the report as JUnit XML. Each execution is a test suite named after its monitor and location with a test case per step.
+// An execution without steps, e.g. as no execution report was retrieved, is a single test case. Failed triggers are reported as errors.
+func (r syntheticReport) toJUnit() ([]byte, error) {
+	testSuites := junitReportTestSuites{
+		Name: fmt.Sprintf("Synthetic test of service %s in stage %s of project %s", r.Service, r.Stage, r.Project),
+	}
+
+	for _, execution := range r.Executions {
+		suite := junitReportTestSuite{
+			Name:       execution.MonitorId + " @ " + execution.LocationId,
+			Time:       formatJUnitSeconds(execution.Duration),
+			Properties: []junitReportProperty{{Name: "executionId", Value: execution.ExecutionId}},
+		}
+
+		for _, step := range execution.Steps {
+			testCase := junitReportTestCase{
+				Name:      step.Name,
+				ClassName: execution.MonitorId,
+				Time:      formatJUnitSeconds(step.ResponseTime),
+			}
+
+			if !step.Successful {
+				testCase.Failure = &junitReportProblem{Message: getReportFailureMessage(step.FailureMessage, step.Status), Type: step.ErrorCode}
+			}
+
+			suite.Cases = append(suite.Cases, testCase)
+		}
+
+		if len(suite.Cases) == 0 {
+			testCase := junitReportTestCase{
+				Name:      "execution",
+				ClassName: execution.MonitorId,
+				Time:      formatJUnitSeconds(execution.Duration),
+			}
+
+			if !execution.Successful {
+				testCase.Failure = &junitReportProblem{Message: getReportFailureMessage(execution.FailureMessage, execution.Status), Type: execution.ErrorCode}
+			}
+
+			suite.Cases = append(suite.Cases, testCase)
+		}
+
+		// an execution failing as a whole, e.g. due to a timeout, is a failure even if all reported steps succeeded
+		if !execution.Successful && countJUnitFailures(suite.Cases) == 0 {
+			suite.Cases[len(suite.Cases)-1].Failure = &junitReportProblem{Message: getReportFailureMessage(execution.FailureMessage, execution.Status), Type: execution.ErrorCode}
+		}
+
+		testSuites.Suites = append(testSuites.Suites, suite)
+	}
+
+	for _, failedTrigger := range r.FailedTriggers {
+		testSuites.Suites = append(testSuites.Suites, junitReportTestSuite{
+			Name: failedTrigger.EntityId + " @ " + failedTrigger.LocationId,
+			Time: formatJUnitSeconds(0),
+			Cases: []junitReportTestCase{
+				{
+					Name:      "trigger",
+					ClassName: failedTrigger.EntityId,
+					Time:      formatJUnitSeconds(0),
+					Error:     &junitReportProblem{Message: getReportFailureMessage(failedTrigger.Cause, "could not be triggered")},
+				},
+			},
+		})
+	}
+
+	for i := range testSuites.Suites {
+		suite := &testSuites.Suites[i]
+		suite.Tests = len(suite.Cases)
+		suite.Failures = countJUnitFailures(suite.Cases)
+		for _, testCase := range suite.Cases {
+			if testCase.Error != nil {
+				suite.Errors++
+			}
+		}
+
+		testSuites.Tests += suite.Tests
+		testSuites.Failures += suite.Failures
+		testSuites.Errors += suite.Errors
+	}
+
+	content, err := xml.MarshalIndent(testSuites, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+
+	return append([]byte(xml.Header), content...), nil
+}
+
+func countJUnitFailures(cases []junitReportTestCase) int {
+	failures := 0
+	for _, testCase := range cases {
+		if testCase.Failure != nil {
+			failures++
+		}
+	}
+	return failures
+}
+
+func getReportFailureMessage(failureMessage string, status string) string {
+	if failureMessage != "" {
+		return failureMessage
+	}
+
+	if status != "" {
+		return status
+	}
+
+	return "failed"
+}
+
+// formatJUnitSeconds formats milliseconds as seconds as expected by JUnit, e.g. 1.234
+func formatJUnitSeconds(millis int64) string {
+	return fmt.Sprintf("%.3f", float64(millis)/1000)
+}
@@ -0,0 +1,148 @@
+package synthetic
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestSyntheticReport(t *testing.T) syntheticReport {
+	event := createTestSyntheticTriggerAdapter(t, map[string]interface{}{})
+	executionData := connector.ExecutionData{
+		BatchId: "1",
+		TriggeredExecutions: []connector.TriggeredExecution{
+			{ExecutionId: "1", MonitorId: "SYNTHETIC_TEST-1", LocationId: "GEOLOCATION-1"},
+			{ExecutionId: "2", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1"},
+			{ExecutionId: "3", MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1"},
+		},
+		FailedTriggers: []connector.ExecutionNotTriggered{{EntityId: "HTTP_CHECK-3", LocationId: "GEOLOCATION-2", Cause: "Location is not available"}},
+		FailedExecutions: []connector.ExecutionNotSuccessful{
+			{ExecutionId: "1", MonitorId: "SYNTHETIC_TEST-1", LocationId: "GEOLOCATION-1"},
+			{ExecutionId: "3", MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1"},
+		},
+		SuccessRate: 33.33,
+		Executions: []connector.ExecutionReport{
+			{
+				ExecutionId:   "1",
+				MonitorId:     "SYNTHETIC_TEST-1",
+				LocationId:    "GEOLOCATION-1",
+				SimpleResults: connector.ExecutionSimpleResults{StartTimestamp: 1650016800000, Status: "FAILED", ErrorCode: "ELEMENT_NOT_FOUND", FailureMessage: "Element not found", Duration: 2500},
+				FullResults: connector.ExecutionFullResults{
+					ExecutionSteps: []connector.ExecutionStepResult{
+						{StepId: 1, StepName: "Load page", Status: "SUCCESS", ResponseTime: 1000},
+						{StepId: 2, StepName: "Click login", Status: "FAILED", ResponseTime: 1500, ErrorCode: "ELEMENT_NOT_FOUND", FailureMessage: "Element not found"},
+					},
+				},
+			},
+			{
+				ExecutionId:   "2",
+				MonitorId:     "HTTP_CHECK-1",
+				LocationId:    "GEOLOCATION-1",
+				SimpleResults: connector.ExecutionSimpleResults{StartTimestamp: 1650016800000, Status: "SUCCESS", Duration: 250},
+				FullResults: connector.ExecutionFullResults{
+					ExecutionSteps: []connector.ExecutionStepResult{
+						{RequestId: "1", RequestName: "GET /health", Status: "SUCCESS", ResponseStatusCode: 200, ResponseTime: 250},
+					},
+				},
+			},
+		},
+	}
+
+	return newSyntheticReport(event, executionData, &SyntheticTestTimeframe{Start: "2022-04-15T10:00:00.000Z", End: "2022-04-15T10:01:00.000Z", Duration: "1m0s"}, keptnv2.ResultFailed, "success rate 33.33% is below 90%")
+}
+
+func TestNewSyntheticReport(t *testing.T) {
+	report := createTestSyntheticReport(t)
+
+	assert.Equal(t, "context-1", report.KeptnContext)
+	assert.Equal(t, "easytravel", report.Project)
+	assert.Equal(t, "staging", report.Stage)
+	assert.Equal(t, "frontend", report.Service)
+	assert.Equal(t, "1", report.BatchId)
+	assert.Len(t, report.FailedTriggers, 1)
+
+	assert.Equal(t, []syntheticReportExecution{
+		{
+			ExecutionId:    "1",
+			MonitorId:      "SYNTHETIC_TEST-1",
+			LocationId:     "GEOLOCATION-1",
+			Status:         "FAILED",
+			ErrorCode:      "ELEMENT_NOT_FOUND",
+			FailureMessage: "Element not found",
+			StartTimestamp: 1650016800000,
+			Duration:       2500,
+			Steps: []syntheticReportStep{
+				{Name: "Load page", Successful: true, Status: "SUCCESS", ResponseTime: 1000},
+				{Name: "Click login", Status: "FAILED", ResponseTime: 1500, ErrorCode: "ELEMENT_NOT_FOUND", FailureMessage: "Element not found"},
+			},
+		},
+		{
+			ExecutionId:    "2",
+			MonitorId:      "HTTP_CHECK-1",
+			LocationId:     "GEOLOCATION-1",
+			Successful:     true,
+			Status:         "SUCCESS",
+			StartTimestamp: 1650016800000,
+			Duration:       250,
+			Steps: []syntheticReportStep{
+				{Name: "GET /health", Successful: true, Status: "SUCCESS", ResponseStatusCode: 200, ResponseTime: 250},
+			},
+		},
+		{
+			ExecutionId: "3",
+			MonitorId:   "HTTP_CHECK-2",
+			LocationId:  "GEOLOCATION-1",
+			Steps:       []syntheticReportStep{},
+		},
+	}, report.Executions)
+}
+
+func TestSyntheticReport_ToJUnit(t *testing.T) {
+	content, err := createTestSyntheticReport(t).toJUnit()
+	assert.NoError(t, err)
+
+	testSuites := junitReportTestSuites{}
+	err = xml.Unmarshal(content, &testSuites)
+	assert.NoError(t, err)
+
+	assert.Equal(t, 5, testSuites.Tests)
+	assert.Equal(t, 2, testSuites.Failures)
+	assert.Equal(t, 1, testSuites.Errors)
+
+	if !assert.Len(t, testSuites.Suites, 4) {
+		return
+	}
+
+	assert.Equal(t, "SYNTHETIC_TEST-1 @ GEOLOCATION-1", testSuites.Suites[0].Name)
+	assert.Equal(t, "2.500", testSuites.Suites[0].Time)
+	assert.Equal(t, []junitReportProperty{{Name: "executionId", Value: "1"}}, testSuites.Suites[0].Properties)
+	assert.Equal(t, []junitReportTestCase{
+		{Name: "Load page", ClassName: "SYNTHETIC_TEST-1", Time: "1.000"},
+		{Name: "Click login", ClassName: "SYNTHETIC_TEST-1", Time: "1.500", Failure: &junitReportProblem{Message: "Element not found", Type: "ELEMENT_NOT_FOUND"}},
+	}, testSuites.Suites[0].Cases)
+
+	assert.Equal(t, 0, testSuites.Suites[1].Failures)
+
+	// without an execution report, the execution is reported as a single failed test case
+	assert.Equal(t, []junitReportTestCase{
+		{Name: "execution", ClassName: "HTTP_CHECK-2", Time: "0.000", Failure: &junitReportProblem{Message: "failed"}},
+	}, testSuites.Suites[2].Cases)
+
+	assert.Equal(t, "HTTP_CHECK-3 @ GEOLOCATION-2", testSuites.Suites[3].Name)
+	assert.Equal(t, &junitReportProblem{Message: "Location is not available"}, testSuites.Suites[3].Cases[0].Error)
+}
+
+func TestSyntheticReport_ToJSON(t *testing.T) {
+	content, err := createTestSyntheticReport(t).toJSON()
+	assert.NoError(t, err)
+
+	report := syntheticReport{}
+	err = json.Unmarshal(content, &report)
+	assert.NoError(t, err)
+
+	assert.Equal(t, createTestSyntheticReport(t), report)
+}
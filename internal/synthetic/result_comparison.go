@@ -0,0 +1,177 @@
+package synthetic
+
+import (
+	"fmt"
+	"math"
+	"strings"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	log "github.com/sirupsen/logrus"
+)
+
+// SyntheticTestComparison is the difference between the results of a synthetic test and the previous test of the service in the same stage.
+type SyntheticTestComparison struct {
+	PreviousBatchId string `json:"previousBatchId"`
+	// NewlyFailingMonitorIds are the monitors with failed executions which succeeded in the previous test
+	NewlyFailingMonitorIds []string `json:"newlyFailingMonitorIds"`
+	// RecoveredMonitorIds are the monitors which succeeded after failed executions in the previous test
+	RecoveredMonitorIds     []string                 `json:"recoveredMonitorIds"`
+	ResponseTimeRegressions []ResponseTimeRegression `json:"responseTimeRegressions"`
+}
+
+// ResponseTimeRegression is the increase of the response time of a monitor at a location compared to the previous test.
+type ResponseTimeRegression struct {
+	MonitorId            string `json:"monitorId"`
+	LocationId           string `json:"locationId"`
+	PreviousResponseTime int64  `json:"previousResponseTime"`
+	ResponseTime         int64  `json:"responseTime"`
+	// Increase is the increase of the response time in percent
+	Increase float64 `json:"increase"`
+}
+
+// isRegression returns true if monitors fail which succeeded in the previous test or response times regressed.
+func (c SyntheticTestComparison) isRegression() bool {
+	return len(c.NewlyFailingMonitorIds) > 0 || len(c.ResponseTimeRegressions) > 0
+}
+
+// compareWithPreviousTest compares the results with the previous test of the service in the same stage if requested and the execution was waited for.
+func (eh *SyntheticTriggerEventHandler) compareWithPreviousTest(executionData connector.ExecutionData) *SyntheticTestComparison {
+	comparisonConfig := eh.getComparison()
+	if comparisonConfig == nil || eh.startTime.IsZero() {
+		return nil
+	}
+
+	previous := SyntheticTriggerFinishedEventData{}
+	found, err := eh.eClient.GetPreviousTestFinishedEventData(eh.event, adapter.GetEventSource(), &previous)
+	if err != nil {
+		log.WithError(err).Error("Could not retrieve previous synthetic test to compare with")
+		return nil
+	}
+
+	// results are only available if the previous test waited for the execution
+	if !found || previous.Test == nil {
+		log.Info("No previous synthetic test with results to compare with")
+		return nil
+	}
+
+	comparison := compareTestResults(previous.SyntheticExecution, executionData, *comparisonConfig)
+	return &comparison
+}
+
+// compareTestResults compares the results of a test with the ones of the previous test. Only monitors and monitor and location pairs executed in both tests are compared.
+// Response times are the durations of successful executions and only compared if a maximum increase is configured.
+func compareTestResults(previous SyntheticExecution, current connector.ExecutionData, comparisonConfig config.SyntheticComparison) SyntheticTestComparison {
+	comparison := SyntheticTestComparison{
+		PreviousBatchId:         previous.BatchId,
+		NewlyFailingMonitorIds:  []string{},
+		RecoveredMonitorIds:     []string{},
+		ResponseTimeRegressions: []ResponseTimeRegression{},
+	}
+
+	previousMonitorIds := make(map[string]bool, len(previous.MonitorIds))
+	for _, monitorId := range previous.MonitorIds {
+		previousMonitorIds[monitorId] = true
+	}
+
+	previousFailingMonitorIds := getFailingMonitorIds(previous.FailedExecutions)
+	failingMonitorIds := getFailingMonitorIds(current.FailedExecutions)
+	for _, monitorId := range current.MonitorIds {
+		if !previousMonitorIds[monitorId] {
+			continue
+		}
+
+		if failingMonitorIds[monitorId] && !previousFailingMonitorIds[monitorId] {
+			comparison.NewlyFailingMonitorIds = append(comparison.NewlyFailingMonitorIds, monitorId)
+		}
+
+		if !failingMonitorIds[monitorId] && previousFailingMonitorIds[monitorId] {
+			comparison.RecoveredMonitorIds = append(comparison.RecoveredMonitorIds, monitorId)
+		}
+	}
+
+	if comparisonConfig.MaxResponseTimeIncrease == nil {
+		return comparison
+	}
+
+	previousResponseTimes := getResponseTimes(previous.Executions, previous.FailedExecutions)
+	responseTimes := getResponseTimes(current.Executions, current.FailedExecutions)
+	comparedMonitorLocations := make(map[connector.MonitorLocation]bool, len(responseTimes))
+	for _, report := range current.Executions {
+		monitorLocation := connector.MonitorLocation{MonitorId: report.MonitorId, LocationId: report.LocationId}
+		if comparedMonitorLocations[monitorLocation] {
+			continue
+		}
+		comparedMonitorLocations[monitorLocation] = true
+
+		responseTime, isSuccessful := responseTimes[monitorLocation]
+		previousResponseTime, wasSuccessful := previousResponseTimes[monitorLocation]
+		if !isSuccessful || !wasSuccessful || previousResponseTime <= 0 {
+			continue
+		}
+
+		increase := math.Round(float64(responseTime-previousResponseTime)/float64(previousResponseTime)*10000) / 100
+		if increase > *comparisonConfig.MaxResponseTimeIncrease {
+			comparison.ResponseTimeRegressions = append(comparison.ResponseTimeRegressions, ResponseTimeRegression{
+				MonitorId:            report.MonitorId,
+				LocationId:           report.LocationId,
+				PreviousResponseTime: previousResponseTime,
+				ResponseTime:         responseTime,
+				Increase:             increase,
+			})
+		}
+	}
+
+	return comparison
+}
+
+func getFailingMonitorIds(failedExecutions []connector.ExecutionNotSuccessful) map[string]bool {
+	monitorIds := make(map[string]bool, len(failedExecutions))
+	for _, failedExecution := range failedExecutions {
+		monitorIds[failedExecution.MonitorId] = true
+	}
+	return monitorIds
+}
+
+// getResponseTimes gets the duration of the last successful execution of each monitor and location pair, i.e. of the last retry.
+func getResponseTimes(executions []connector.ExecutionReport, failedExecutions []connector.ExecutionNotSuccessful) map[connector.MonitorLocation]int64 {
+	failedExecutionIds := make(map[string]bool, len(failedExecutions))
+	for _, failedExecution := range failedExecutions {
+		failedExecutionIds[failedExecution.ExecutionId] = true
+	}
+
+	responseTimes := make(map[connector.MonitorLocation]int64, len(executions))
+	for _, report := range executions {
+		if failedExecutionIds[report.ExecutionId] {
+			continue
+		}
+
+		responseTimes[connector.MonitorLocation{MonitorId: report.MonitorId, LocationId: report.LocationId}] = report.SimpleResults.Duration
+	}
+	return responseTimes
+}
+
+// withComparison adds the regressions found by the comparison to the violations. A passing result becomes a warning in case of a regression.
+func (e resultEvaluation) withComparison(comparison *SyntheticTestComparison) resultEvaluation {
+	if comparison == nil || !comparison.isRegression() {
+		return e
+	}
+
+	violations := append([]string{}, e.violations...)
+	if len(comparison.NewlyFailingMonitorIds) > 0 {
+		violations = append(violations, fmt.Sprintf("monitors %s failed which succeeded in the previous test", strings.Join(comparison.NewlyFailingMonitorIds, ", ")))
+	}
+
+	for _, regression := range comparison.ResponseTimeRegressions {
+		violations = append(violations, fmt.Sprintf("response time of %s at %s increased by %.2f%% from %dms to %dms", regression.MonitorId, regression.LocationId, regression.Increase, regression.PreviousResponseTime, regression.ResponseTime))
+	}
+
+	result := e.result
+	if result == keptnv2.ResultPass {
+		result = keptnv2.ResultWarning
+	}
+
+	return resultEvaluation{result: result, violations: violations}
+}
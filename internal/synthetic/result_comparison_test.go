@@ -0,0 +1,139 @@
+package synthetic
+
+import (
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/assert"
+)
+
+func createTestExecutionReport(executionId string, monitorId string, locationId string, duration int64) connector.ExecutionReport {
+	return connector.ExecutionReport{
+		ExecutionId:   executionId,
+		MonitorId:     monitorId,
+		LocationId:    locationId,
+		SimpleResults: connector.ExecutionSimpleResults{Duration: duration},
+	}
+}
+
+func TestCompareTestResults(t *testing.T) {
+	maxIncrease := 20.0
+
+	previous := SyntheticExecution{
+		BatchId:    "1",
+		MonitorIds: []string{"HTTP_CHECK-1", "HTTP_CHECK-2", "HTTP_CHECK-3"},
+		FailedExecutions: []connector.ExecutionNotSuccessful{
+			{ExecutionId: "3", MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1"},
+		},
+		Executions: []connector.ExecutionReport{
+			createTestExecutionReport("1", "HTTP_CHECK-1", "GEOLOCATION-1", 100),
+			createTestExecutionReport("2", "HTTP_CHECK-1", "GEOLOCATION-2", 100),
+			createTestExecutionReport("3", "HTTP_CHECK-2", "GEOLOCATION-1", 5000),
+			createTestExecutionReport("4", "HTTP_CHECK-3", "GEOLOCATION-1", 100),
+		},
+	}
+
+	current := connector.ExecutionData{
+		BatchId:    "2",
+		MonitorIds: []string{"HTTP_CHECK-1", "HTTP_CHECK-2", "HTTP_CHECK-3", "HTTP_CHECK-4"},
+		FailedExecutions: []connector.ExecutionNotSuccessful{
+			{ExecutionId: "14", MonitorId: "HTTP_CHECK-3", LocationId: "GEOLOCATION-1"},
+			{ExecutionId: "15", MonitorId: "HTTP_CHECK-4", LocationId: "GEOLOCATION-1"},
+		},
+		Executions: []connector.ExecutionReport{
+			createTestExecutionReport("11", "HTTP_CHECK-1", "GEOLOCATION-1", 120),
+			createTestExecutionReport("12", "HTTP_CHECK-1", "GEOLOCATION-2", 150),
+			createTestExecutionReport("13", "HTTP_CHECK-2", "GEOLOCATION-1", 200),
+			createTestExecutionReport("14", "HTTP_CHECK-3", "GEOLOCATION-1", 300),
+			createTestExecutionReport("15", "HTTP_CHECK-4", "GEOLOCATION-1", 100),
+		},
+	}
+
+	tests := []struct {
+		name             string
+		comparisonConfig config.SyntheticComparison
+		want             SyntheticTestComparison
+	}{
+		{
+			name:             "without response times",
+			comparisonConfig: config.SyntheticComparison{},
+			want: SyntheticTestComparison{
+				PreviousBatchId:         "1",
+				NewlyFailingMonitorIds:  []string{"HTTP_CHECK-3"},
+				RecoveredMonitorIds:     []string{"HTTP_CHECK-2"},
+				ResponseTimeRegressions: []ResponseTimeRegression{},
+			},
+		},
+		{
+			name:             "with response times",
+			comparisonConfig: config.SyntheticComparison{MaxResponseTimeIncrease: &maxIncrease},
+			want: SyntheticTestComparison{
+				PreviousBatchId:        "1",
+				NewlyFailingMonitorIds: []string{"HTTP_CHECK-3"},
+				RecoveredMonitorIds:    []string{"HTTP_CHECK-2"},
+				ResponseTimeRegressions: []ResponseTimeRegression{
+					{MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-2", PreviousResponseTime: 100, ResponseTime: 150, Increase: 50},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, compareTestResults(previous, current, tt.comparisonConfig))
+		})
+	}
+}
+
+func TestResultEvaluation_WithComparison(t *testing.T) {
+	regression := &SyntheticTestComparison{
+		NewlyFailingMonitorIds: []string{"HTTP_CHECK-1"},
+		ResponseTimeRegressions: []ResponseTimeRegression{
+			{MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1", PreviousResponseTime: 100, ResponseTime: 150, Increase: 50},
+		},
+	}
+
+	tests := []struct {
+		name        string
+		evaluation  resultEvaluation
+		comparison  *SyntheticTestComparison
+		wantResult  keptnv2.ResultType
+		wantMessage string
+	}{
+		{
+			name:       "not compared",
+			evaluation: resultEvaluation{result: keptnv2.ResultPass},
+			wantResult: keptnv2.ResultPass,
+		},
+		{
+			name:       "recovered only",
+			evaluation: resultEvaluation{result: keptnv2.ResultPass},
+			comparison: &SyntheticTestComparison{RecoveredMonitorIds: []string{"HTTP_CHECK-1"}},
+			wantResult: keptnv2.ResultPass,
+		},
+		{
+			name:        "regression of passed test",
+			evaluation:  resultEvaluation{result: keptnv2.ResultPass},
+			comparison:  regression,
+			wantResult:  keptnv2.ResultWarning,
+			wantMessage: "synthetic test result warning: monitors HTTP_CHECK-1 failed which succeeded in the previous test, response time of HTTP_CHECK-2 at GEOLOCATION-1 increased by 50.00% from 100ms to 150ms",
+		},
+		{
+			name:        "regression of failed test",
+			evaluation:  resultEvaluation{result: keptnv2.ResultFailed, violations: []string{"success rate 50.00% is below 100.00%"}},
+			comparison:  &SyntheticTestComparison{NewlyFailingMonitorIds: []string{"HTTP_CHECK-1"}},
+			wantResult:  keptnv2.ResultFailed,
+			wantMessage: "synthetic test result fail: success rate 50.00% is below 100.00%, monitors HTTP_CHECK-1 failed which succeeded in the previous test",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluation := tt.evaluation.withComparison(tt.comparison)
+			assert.Equal(t, tt.wantResult, evaluation.result)
+			assert.Equal(t, tt.wantMessage, evaluation.message())
+		})
+	}
+}
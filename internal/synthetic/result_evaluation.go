@@ -0,0 +1,62 @@
+package synthetic
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// resultEvaluation is the result of evaluating the results of a synthetic batch against thresholds.
+type resultEvaluation struct {
+	result     keptnv2.ResultType
+	violations []string
+}
+
+// message returns a message describing the violated thresholds or an empty string if none were violated.
+func (e resultEvaluation) message() string {
+	if len(e.violations) == 0 {
+		return ""
+	}
+
+	return fmt.Sprintf("synthetic test result %s: %s", e.result, strings.Join(e.violations, ", "))
+}
+
+// evaluateResult evaluates the results of a synthetic batch against the thresholds.
+// The success rate and failed executions are only evaluated if isExecutionAvailable is true, i.e. if the service waited for the execution.
+func evaluateResult(executionData connector.ExecutionData, thresholds *config.SyntheticThresholds, isExecutionAvailable bool) resultEvaluation {
+	if thresholds == nil || thresholds.Pass == nil {
+		return resultEvaluation{result: keptnv2.ResultPass}
+	}
+
+	passViolations := getThresholdViolations(executionData, thresholds.Pass, isExecutionAvailable)
+	if len(passViolations) == 0 {
+		return resultEvaluation{result: keptnv2.ResultPass}
+	}
+
+	if thresholds.Warning != nil && len(getThresholdViolations(executionData, thresholds.Warning, isExecutionAvailable)) == 0 {
+		return resultEvaluation{result: keptnv2.ResultWarning, violations: passViolations}
+	}
+
+	return resultEvaluation{result: keptnv2.ResultFailed, violations: passViolations}
+}
+
+func getThresholdViolations(executionData connector.ExecutionData, threshold *config.SyntheticThreshold, isExecutionAvailable bool) []string {
+	violations := []string{}
+
+	if isExecutionAvailable && threshold.MinSuccessRate != nil && executionData.SuccessRate < *threshold.MinSuccessRate {
+		violations = append(violations, fmt.Sprintf("success rate %.2f%% is below %.2f%%", executionData.SuccessRate, *threshold.MinSuccessRate))
+	}
+
+	if isExecutionAvailable && threshold.MaxFailedExecutions != nil && len(executionData.FailedExecutions) > *threshold.MaxFailedExecutions {
+		violations = append(violations, fmt.Sprintf("%d failed executions exceed %d", len(executionData.FailedExecutions), *threshold.MaxFailedExecutions))
+	}
+
+	if threshold.MaxFailedTriggers != nil && len(executionData.FailedTriggers) > *threshold.MaxFailedTriggers {
+		violations = append(violations, fmt.Sprintf("%d failed triggers exceed %d", len(executionData.FailedTriggers), *threshold.MaxFailedTriggers))
+	}
+
+	return violations
+}
@@ -0,0 +1,96 @@
+package synthetic
+
+import (
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEvaluateResult(t *testing.T) {
+	minSuccessRate100 := 100.0
+	minSuccessRate50 := 50.0
+	maxZero := 0
+	maxOne := 1
+
+	thresholds := &config.SyntheticThresholds{
+		Pass: &config.SyntheticThreshold{
+			MinSuccessRate:      &minSuccessRate100,
+			MaxFailedExecutions: &maxZero,
+			MaxFailedTriggers:   &maxZero,
+		},
+		Warning: &config.SyntheticThreshold{
+			MinSuccessRate:    &minSuccessRate50,
+			MaxFailedTriggers: &maxOne,
+		},
+	}
+
+	tests := []struct {
+		name                 string
+		executionData        connector.ExecutionData
+		thresholds           *config.SyntheticThresholds
+		isExecutionAvailable bool
+		wantResult           keptnv2.ResultType
+		wantMessage          string
+	}{
+		{
+			name:                 "no thresholds",
+			executionData:        connector.ExecutionData{SuccessRate: 0},
+			isExecutionAvailable: true,
+			wantResult:           keptnv2.ResultPass,
+		},
+		{
+			name:                 "pass",
+			executionData:        connector.ExecutionData{SuccessRate: 100},
+			thresholds:           thresholds,
+			isExecutionAvailable: true,
+			wantResult:           keptnv2.ResultPass,
+		},
+		{
+			name: "warning",
+			executionData: connector.ExecutionData{
+				SuccessRate:      75,
+				FailedExecutions: []connector.ExecutionNotSuccessful{{ExecutionId: "1"}},
+			},
+			thresholds:           thresholds,
+			isExecutionAvailable: true,
+			wantResult:           keptnv2.ResultWarning,
+			wantMessage:          "synthetic test result warning: success rate 75.00% is below 100.00%, 1 failed executions exceed 0",
+		},
+		{
+			name: "fail",
+			executionData: connector.ExecutionData{
+				SuccessRate:    0,
+				FailedTriggers: []connector.ExecutionNotTriggered{{EntityId: "1"}, {EntityId: "2"}},
+			},
+			thresholds:           thresholds,
+			isExecutionAvailable: true,
+			wantResult:           keptnv2.ResultFailed,
+			wantMessage:          "synthetic test result fail: success rate 0.00% is below 100.00%, 2 failed triggers exceed 0",
+		},
+		{
+			name:                 "execution not available",
+			executionData:        connector.ExecutionData{SuccessRate: 0},
+			thresholds:           thresholds,
+			isExecutionAvailable: false,
+			wantResult:           keptnv2.ResultPass,
+		},
+		{
+			name:                 "fail without warning threshold",
+			executionData:        connector.ExecutionData{SuccessRate: 99},
+			thresholds:           &config.SyntheticThresholds{Pass: &config.SyntheticThreshold{MinSuccessRate: &minSuccessRate100}},
+			isExecutionAvailable: true,
+			wantResult:           keptnv2.ResultFailed,
+			wantMessage:          "synthetic test result fail: success rate 99.00% is below 100.00%",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			evaluation := evaluateResult(tt.executionData, tt.thresholds, tt.isExecutionAvailable)
+			assert.Equal(t, tt.wantResult, evaluation.result)
+			assert.Equal(t, tt.wantMessage, evaluation.message())
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package synthetic
+
+import (
+	"math"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+)
+
+// monitorLocationResult is the latest result of a monitor at a location across all attempts.
+type monitorLocationResult struct {
+	triggeredExecution *connector.TriggeredExecution
+	failedExecution    *connector.ExecutionNotSuccessful
+	failedTrigger      *connector.ExecutionNotTriggered
+}
+
+// getFailedMonitorLocations returns the monitor and location pairs which failed to be triggered or executed in the specified attempt.
+func getFailedMonitorLocations(executionData connector.ExecutionData) []connector.MonitorLocation {
+	monitorLocations := []connector.MonitorLocation{}
+	for _, failedTrigger := range executionData.FailedTriggers {
+		monitorLocations = appendUniqueMonitorLocation(monitorLocations, connector.MonitorLocation{MonitorId: failedTrigger.EntityId, LocationId: failedTrigger.LocationId})
+	}
+
+	for _, failedExecution := range executionData.FailedExecutions {
+		monitorLocations = appendUniqueMonitorLocation(monitorLocations, connector.MonitorLocation{MonitorId: failedExecution.MonitorId, LocationId: failedExecution.LocationId})
+	}
+
+	return monitorLocations
+}
+
+func appendUniqueMonitorLocation(monitorLocations []connector.MonitorLocation, monitorLocation connector.MonitorLocation) []connector.MonitorLocation {
+	for _, existingMonitorLocation := range monitorLocations {
+		if existingMonitorLocation == monitorLocation {
+			return monitorLocations
+		}
+	}
+
+	return append(monitorLocations, monitorLocation)
+}
+
+// mergeExecutionAttempts merges the initial attempt and all retries into a single result.
+// The result of each monitor and location pair is taken from the last attempt it was part of.
+// As for a single batch, the success rate is calculated over the triggered pairs only.
+// All attempts are recorded in the merged result. A single attempt is returned as is.
+func mergeExecutionAttempts(attempts []connector.ExecutionData) connector.ExecutionData {
+	if len(attempts) == 0 {
+		return connector.ExecutionData{}
+	}
+
+	if len(attempts) == 1 {
+		return attempts[0]
+	}
+
+	order := []connector.MonitorLocation{}
+	results := make(map[connector.MonitorLocation]monitorLocationResult)
+	reportsByExecutionId := make(map[string]connector.ExecutionReport)
+
+	setResult := func(monitorLocation connector.MonitorLocation, result monitorLocationResult) {
+		order = appendUniqueMonitorLocation(order, monitorLocation)
+		results[monitorLocation] = result
+	}
+
+	for _, attempt := range attempts {
+		for _, report := range attempt.Executions {
+			reportsByExecutionId[report.ExecutionId] = report
+		}
+
+		failedExecutionsById := make(map[string]connector.ExecutionNotSuccessful, len(attempt.FailedExecutions))
+		for _, failedExecution := range attempt.FailedExecutions {
+			failedExecutionsById[failedExecution.ExecutionId] = failedExecution
+		}
+
+		for i := range attempt.TriggeredExecutions {
+			triggeredExecution := attempt.TriggeredExecutions[i]
+
+			// a monitor which could not be triggered at all is resolved once it is triggered at any location
+			delete(results, connector.MonitorLocation{MonitorId: triggeredExecution.MonitorId})
+
+			result := monitorLocationResult{triggeredExecution: &triggeredExecution}
+			if failedExecution, found := failedExecutionsById[triggeredExecution.ExecutionId]; found {
+				result.failedExecution = &failedExecution
+			}
+
+			setResult(connector.MonitorLocation{MonitorId: triggeredExecution.MonitorId, LocationId: triggeredExecution.LocationId}, result)
+		}
+
+		for i := range attempt.FailedTriggers {
+			failedTrigger := attempt.FailedTriggers[i]
+			setResult(connector.MonitorLocation{MonitorId: failedTrigger.EntityId, LocationId: failedTrigger.LocationId}, monitorLocationResult{failedTrigger: &failedTrigger})
+		}
+	}
+
+	merged := connector.ExecutionData{
+		BatchId:             attempts[0].BatchId,
+		MonitorIds:          attempts[0].MonitorIds,
+		UrlOverrides:        attempts[0].UrlOverrides,
+		ExecutionIds:        []string{},
+		TriggeredExecutions: []connector.TriggeredExecution{},
+		FailedTriggers:      []connector.ExecutionNotTriggered{},
+		FailedExecutions:    []connector.ExecutionNotSuccessful{},
+	}
+
+	total := 0
+	successful := 0
+	for _, monitorLocation := range order {
+		result, found := results[monitorLocation]
+		if !found {
+			continue
+		}
+
+		if result.failedTrigger != nil {
+			merged.FailedTriggers = append(merged.FailedTriggers, *result.failedTrigger)
+			continue
+		}
+
+		total++
+
+		merged.ExecutionIds = append(merged.ExecutionIds, result.triggeredExecution.ExecutionId)
+		merged.TriggeredExecutions = append(merged.TriggeredExecutions, *result.triggeredExecution)
+		if report, found := reportsByExecutionId[result.triggeredExecution.ExecutionId]; found {
+			merged.Executions = append(merged.Executions, report)
+		}
+
+		if result.failedExecution != nil {
+			merged.FailedExecutions = append(merged.FailedExecutions, *result.failedExecution)
+			continue
+		}
+
+		successful++
+	}
+
+	if total > 0 {
+		merged.SuccessRate = math.Round(float64(successful)/float64(total)*10000) / 100
+	}
+
+	for _, attempt := range attempts {
+		merged.Attempts = append(merged.Attempts, connector.ExecutionAttempt{
+			BatchId:          attempt.BatchId,
+			ExecutionIds:     attempt.ExecutionIds,
+			FailedTriggers:   attempt.FailedTriggers,
+			FailedExecutions: attempt.FailedExecutions,
+			SuccessRate:      attempt.SuccessRate,
+		})
+	}
+
+	return merged
+}
@@ -0,0 +1,114 @@
+package synthetic
+
+import (
+	"testing"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetFailedMonitorLocations(t *testing.T) {
+	executionData := connector.ExecutionData{
+		FailedTriggers: []connector.ExecutionNotTriggered{
+			{EntityId: "SYNTHETIC_TEST-2", Cause: "monitor disabled"},
+		},
+		FailedExecutions: []connector.ExecutionNotSuccessful{
+			{ExecutionId: "1", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1"},
+			{ExecutionId: "2", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1"},
+		},
+	}
+
+	assert.Equal(t, []connector.MonitorLocation{
+		{MonitorId: "SYNTHETIC_TEST-2"},
+		{MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1"},
+	}, getFailedMonitorLocations(executionData))
+
+	assert.Empty(t, getFailedMonitorLocations(connector.ExecutionData{}))
+}
+
+func TestMergeExecutionAttempts(t *testing.T) {
+	firstAttempt := connector.ExecutionData{
+		BatchId:      "batch-1",
+		MonitorIds:   []string{"HTTP_CHECK-1", "HTTP_CHECK-2"},
+		ExecutionIds: []string{"1", "2", "3"},
+		TriggeredExecutions: []connector.TriggeredExecution{
+			{ExecutionId: "1", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1"},
+			{ExecutionId: "2", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-2"},
+			{ExecutionId: "3", MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1"},
+		},
+		FailedTriggers: []connector.ExecutionNotTriggered{
+			{EntityId: "SYNTHETIC_TEST-3", Cause: "monitor disabled"},
+		},
+		FailedExecutions: []connector.ExecutionNotSuccessful{
+			{ExecutionId: "2", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-2"},
+			{ExecutionId: "3", MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1"},
+		},
+		SuccessRate: 33.33,
+		Executions: []connector.ExecutionReport{
+			{ExecutionId: "1", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1"},
+			{ExecutionId: "2", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-2"},
+			{ExecutionId: "3", MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1"},
+		},
+	}
+
+	secondAttempt := connector.ExecutionData{
+		BatchId:      "batch-2",
+		MonitorIds:   []string{"HTTP_CHECK-1", "HTTP_CHECK-2", "SYNTHETIC_TEST-3"},
+		ExecutionIds: []string{"4", "5", "6"},
+		TriggeredExecutions: []connector.TriggeredExecution{
+			{ExecutionId: "4", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-2"},
+			{ExecutionId: "5", MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1"},
+			{ExecutionId: "6", MonitorId: "SYNTHETIC_TEST-3", LocationId: "GEOLOCATION-1"},
+		},
+		FailedTriggers: []connector.ExecutionNotTriggered{},
+		FailedExecutions: []connector.ExecutionNotSuccessful{
+			{ExecutionId: "5", MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1"},
+		},
+		SuccessRate: 66.67,
+		Executions: []connector.ExecutionReport{
+			{ExecutionId: "4", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-2"},
+			{ExecutionId: "5", MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1"},
+			{ExecutionId: "6", MonitorId: "SYNTHETIC_TEST-3", LocationId: "GEOLOCATION-1"},
+		},
+	}
+
+	merged := mergeExecutionAttempts([]connector.ExecutionData{firstAttempt, secondAttempt})
+
+	assert.Equal(t, "batch-1", merged.BatchId)
+	assert.Equal(t, []string{"HTTP_CHECK-1", "HTTP_CHECK-2"}, merged.MonitorIds)
+	assert.Equal(t, []string{"1", "4", "5", "6"}, merged.ExecutionIds)
+	assert.Empty(t, merged.FailedTriggers)
+	assert.Equal(t, []connector.ExecutionNotSuccessful{
+		{ExecutionId: "5", MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1"},
+	}, merged.FailedExecutions)
+	assert.Equal(t, 75.0, merged.SuccessRate)
+	if assert.Equal(t, 4, len(merged.Executions)) {
+		assert.Equal(t, "4", merged.Executions[1].ExecutionId)
+	}
+
+	if assert.Equal(t, 2, len(merged.Attempts)) {
+		assert.Equal(t, "batch-1", merged.Attempts[0].BatchId)
+		assert.Equal(t, 33.33, merged.Attempts[0].SuccessRate)
+		assert.Equal(t, "batch-2", merged.Attempts[1].BatchId)
+		assert.Equal(t, 66.67, merged.Attempts[1].SuccessRate)
+	}
+}
+
+func TestMergeExecutionAttempts_SingleAttempt(t *testing.T) {
+	attempt := connector.ExecutionData{BatchId: "batch-1", SuccessRate: 50}
+
+	assert.Equal(t, attempt, mergeExecutionAttempts([]connector.ExecutionData{attempt}))
+}
+
+func TestMergeExecutionAttempts_FailedTriggerPersists(t *testing.T) {
+	failedTrigger := connector.ExecutionNotTriggered{EntityId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1", Cause: "location not assigned"}
+
+	merged := mergeExecutionAttempts([]connector.ExecutionData{
+		{BatchId: "batch-1", FailedTriggers: []connector.ExecutionNotTriggered{failedTrigger}},
+		{BatchId: "batch-2", FailedTriggers: []connector.ExecutionNotTriggered{failedTrigger}},
+	})
+
+	assert.Equal(t, []connector.ExecutionNotTriggered{failedTrigger}, merged.FailedTriggers)
+	assert.Empty(t, merged.ExecutionIds)
+	assert.Equal(t, 0.0, merged.SuccessRate)
+}
@@ -0,0 +1,122 @@
+package synthetic
+
+import (
+	"context"
+	"sync"
+)
+
+const shutdownAbortReason = "the service is shutting down"
+
+// RunningTests keeps track of the synthetic tests running in this service instance by Keptn context, so that they can be aborted by an event of the same Keptn context.
+type RunningTests struct {
+	mutex sync.Mutex
+	tests map[string][]*runningTest
+}
+
+// DefaultRunningTests are the synthetic tests running in this service instance.
+var DefaultRunningTests = NewRunningTests()
+
+// NewRunningTests creates a new RunningTests.
+func NewRunningTests() *RunningTests {
+	return &RunningTests{
+		tests: make(map[string][]*runningTest),
+	}
+}
+
+// add adds a running test of the Keptn context and returns it as well as a function removing it once the test is finished.
+// The context of the test is done once the test is aborted or workCtx is done.
+func (r *RunningTests) add(workCtx context.Context, keptnContext string) (*runningTest, func()) {
+	test := newRunningTest(workCtx)
+
+	r.mutex.Lock()
+	r.tests[keptnContext] = append(r.tests[keptnContext], test)
+	r.mutex.Unlock()
+
+	return test, func() {
+		r.remove(keptnContext, test)
+		test.cancel()
+	}
+}
+
+func (r *RunningTests) remove(keptnContext string, test *runningTest) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	tests := make([]*runningTest, 0, len(r.tests[keptnContext]))
+	for _, t := range r.tests[keptnContext] {
+		if t != test {
+			tests = append(tests, t)
+		}
+	}
+
+	if len(tests) == 0 {
+		delete(r.tests, keptnContext)
+		return
+	}
+
+	r.tests[keptnContext] = tests
+}
+
+// Abort aborts all running tests of the Keptn context for the specified reason and returns the number of aborted tests.
+func (r *RunningTests) Abort(keptnContext string, reason string) int {
+	r.mutex.Lock()
+	tests := r.tests[keptnContext]
+	r.mutex.Unlock()
+
+	for _, test := range tests {
+		test.abort(reason)
+	}
+
+	return len(tests)
+}
+
+// runningTest is a synthetic test which can be aborted.
+type runningTest struct {
+	ctx    context.Context
+	cancel context.CancelFunc
+
+	mutex  sync.Mutex
+	reason string
+}
+
+func newRunningTest(workCtx context.Context) *runningTest {
+	ctx, cancel := context.WithCancel(workCtx)
+	return &runningTest{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+func (t *runningTest) abort(reason string) {
+	t.mutex.Lock()
+	if t.reason == "" {
+		t.reason = reason
+	}
+	t.mutex.Unlock()
+
+	t.cancel()
+}
+
+// isAbortedByEvent checks whether the test was aborted by an event rather than by a shutdown of the service.
+func (t *runningTest) isAbortedByEvent() bool {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	return t.reason != ""
+}
+
+// getAbortReason returns why the test was aborted or an empty string if it was not aborted.
+func (t *runningTest) getAbortReason() string {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+
+	if t.reason != "" {
+		return t.reason
+	}
+
+	if t.ctx.Err() != nil {
+		return shutdownAbortReason
+	}
+
+	return ""
+}
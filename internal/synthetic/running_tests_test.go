@@ -0,0 +1,41 @@
+package synthetic
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRunningTests_Abort(t *testing.T) {
+	runningTests := NewRunningTests()
+
+	test1, stop1 := runningTests.add(context.TODO(), "context-1")
+	test2, stop2 := runningTests.add(context.TODO(), "context-1")
+	test3, stop3 := runningTests.add(context.TODO(), "context-2")
+	defer stop3()
+
+	stop2()
+	assert.Equal(t, shutdownAbortReason, test2.getAbortReason(), "a stopped test appears as shut down")
+
+	assert.Equal(t, 1, runningTests.Abort("context-1", "aborted by event"))
+	assert.Error(t, test1.ctx.Err())
+	assert.True(t, test1.isAbortedByEvent())
+	assert.Equal(t, "aborted by event", test1.getAbortReason())
+
+	assert.NoError(t, test3.ctx.Err())
+	assert.Empty(t, test3.getAbortReason())
+
+	stop1()
+	assert.Equal(t, 0, runningTests.Abort("context-1", "aborted by event"))
+}
+
+func TestRunningTest_getAbortReason_Shutdown(t *testing.T) {
+	workCtx, cancel := context.WithCancel(context.TODO())
+	test, stop := NewRunningTests().add(workCtx, "context-1")
+	defer stop()
+
+	cancel()
+	assert.False(t, test.isAbortedByEvent())
+	assert.Equal(t, shutdownAbortReason, test.getAbortReason())
+}
@@ -13,15 +13,26 @@ type SyntheticTriggerStartedEventData struct {
 
 type SyntheticExecution struct {
 	BatchId          string                             `json:"batchId"`
+	MonitorIds       []string                           `json:"monitorIds"`
 	ExecutionIds     []string                           `json:"executionIds"`
 	FailedTriggers   []connector.ExecutionNotTriggered  `json:"failedTriggers"`
 	FailedExecutions []connector.ExecutionNotSuccessful `json:"failedExecutions"`
 	SuccessRate      float64                            `json:"successRate"`
+	Executions       []connector.ExecutionReport        `json:"executions,omitempty"`
+	Attempts         []connector.ExecutionAttempt       `json:"attempts,omitempty"`
+	UrlOverrides     []connector.UrlOverride            `json:"urlOverrides,omitempty"`
+	EphemeralMonitor *EphemeralMonitor                  `json:"ephemeralMonitor,omitempty"`
+	Reports          *SyntheticReports                  `json:"reports,omitempty"`
+	Comparison       *SyntheticTestComparison           `json:"comparison,omitempty"`
+	// QuarantinedMonitorIds are the monitors excluded from the result
+	QuarantinedMonitorIds []string           `json:"quarantinedMonitorIds,omitempty"`
+	Flakiness             []MonitorFlakiness `json:"flakiness,omitempty"`
 }
 
 type SyntheticTriggerFinishedEventData struct {
 	keptnv2.EventData
-	SyntheticExecution SyntheticExecution `json:"syntheticExecution"`
+	Test               *SyntheticTestTimeframe `json:"test,omitempty"`
+	SyntheticExecution SyntheticExecution      `json:"syntheticExecution"`
 }
 
 // SyntheticTriggerStartedEventFactory is a factory for test.started cloud events.
@@ -55,43 +66,76 @@ func (f *SyntheticTriggerStartedEventFactory) CreateCloudEvent() (*cloudevents.E
 
 // SyntheticTriggerFinishedEventFactory is a factory for test.finished cloud events.
 type SyntheticTriggerFinishedEventFactory struct {
-	event         SyntheticTriggerAdapterInterface
-	status        keptnv2.StatusType
-	result        keptnv2.ResultType
-	err           error
-	executionData connector.ExecutionData
+	event                 SyntheticTriggerAdapterInterface
+	status                keptnv2.StatusType
+	result                keptnv2.ResultType
+	err                   error
+	executionData         connector.ExecutionData
+	ephemeralMonitor      *EphemeralMonitor
+	timeframe             *SyntheticTestTimeframe
+	reports               *SyntheticReports
+	comparison            *SyntheticTestComparison
+	flakiness             []MonitorFlakiness
+	quarantinedMonitorIds []string
 }
 
-// NewSucceededSyntheticTriggerFinishedEventFactory creates a new SyntheticTriggerFinishedEventFactory with status succeeded.
-func NewSucceededSyntheticTriggerFinishedEventFactory(event SyntheticTriggerAdapterInterface, executionData connector.ExecutionData, err error) *SyntheticTriggerFinishedEventFactory {
+// NewSucceededSyntheticTriggerFinishedEventFactory creates a new SyntheticTriggerFinishedEventFactory with status succeeded and the specified result.
+// The comparison with the previous test is nil if the results were not compared, the quarantined monitors are the ones excluded from the result.
+func NewSucceededSyntheticTriggerFinishedEventFactory(event SyntheticTriggerAdapterInterface, executionData connector.ExecutionData, ephemeralMonitor *EphemeralMonitor, timeframe *SyntheticTestTimeframe, reports *SyntheticReports, comparison *SyntheticTestComparison, flakiness []MonitorFlakiness, quarantinedMonitorIds []string, result keptnv2.ResultType, err error) *SyntheticTriggerFinishedEventFactory {
 	return &SyntheticTriggerFinishedEventFactory{
-		event:         event,
-		status:        keptnv2.StatusSucceeded,
-		result:        keptnv2.ResultPass,
-		err:           err,
-		executionData: executionData,
+		event:                 event,
+		status:                keptnv2.StatusSucceeded,
+		result:                result,
+		err:                   err,
+		executionData:         executionData,
+		ephemeralMonitor:      ephemeralMonitor,
+		timeframe:             timeframe,
+		reports:               reports,
+		comparison:            comparison,
+		flakiness:             flakiness,
+		quarantinedMonitorIds: quarantinedMonitorIds,
 	}
 }
 
 // NewErroredSyntheticTriggerFinishedEventFactory creates a new SyntheticTriggerFinishedEventFactory with status errored.
-func NewErroredSyntheticTriggerFinishedEventFactory(event SyntheticTriggerAdapterInterface, executionData connector.ExecutionData, err error) *SyntheticTriggerFinishedEventFactory {
+func NewErroredSyntheticTriggerFinishedEventFactory(event SyntheticTriggerAdapterInterface, executionData connector.ExecutionData, ephemeralMonitor *EphemeralMonitor, timeframe *SyntheticTestTimeframe, reports *SyntheticReports, err error) *SyntheticTriggerFinishedEventFactory {
 	return &SyntheticTriggerFinishedEventFactory{
-		event:         event,
-		status:        keptnv2.StatusErrored,
-		result:        keptnv2.ResultFailed,
-		err:           err,
-		executionData: executionData,
+		event:            event,
+		status:           keptnv2.StatusErrored,
+		result:           keptnv2.ResultFailed,
+		err:              err,
+		executionData:    executionData,
+		ephemeralMonitor: ephemeralMonitor,
+		timeframe:        timeframe,
+		reports:          reports,
 	}
 }
 
 // NewWarningSyntheticTriggerFinishedEventFactory creates a new SyntheticTriggerFinishedEventFactory with status unknown, result warning.
-func NewWarningSyntheticTriggerFinishedEventFactory(event SyntheticTriggerAdapterInterface, executionData connector.ExecutionData, err error) *SyntheticTriggerFinishedEventFactory {
+func NewWarningSyntheticTriggerFinishedEventFactory(event SyntheticTriggerAdapterInterface, executionData connector.ExecutionData, ephemeralMonitor *EphemeralMonitor, timeframe *SyntheticTestTimeframe, reports *SyntheticReports, err error) *SyntheticTriggerFinishedEventFactory {
 	return &SyntheticTriggerFinishedEventFactory{
-		event:         event,
-		status:        keptnv2.StatusUnknown,
-		result:        keptnv2.ResultWarning,
-		err:           err,
-		executionData: executionData,
+		event:            event,
+		status:           keptnv2.StatusUnknown,
+		result:           keptnv2.ResultWarning,
+		err:              err,
+		executionData:    executionData,
+		ephemeralMonitor: ephemeralMonitor,
+		timeframe:        timeframe,
+		reports:          reports,
+	}
+}
+
+// NewAbortedSyntheticTriggerFinishedEventFactory creates a new SyntheticTriggerFinishedEventFactory with status aborted, result warning, as the results are incomplete.
+func NewAbortedSyntheticTriggerFinishedEventFactory(event SyntheticTriggerAdapterInterface, executionData connector.ExecutionData, ephemeralMonitor *EphemeralMonitor, timeframe *SyntheticTestTimeframe, reports *SyntheticReports, err error) *SyntheticTriggerFinishedEventFactory {
+	return &SyntheticTriggerFinishedEventFactory{
+		event:            event,
+		status:           keptnv2.StatusAborted,
+		result:           keptnv2.ResultWarning,
+		err:              err,
+		executionData:    executionData,
+		ephemeralMonitor: ephemeralMonitor,
+		timeframe:        timeframe,
+		reports:          reports,
 	}
 }
 
@@ -113,12 +157,22 @@ func (f *SyntheticTriggerFinishedEventFactory) CreateCloudEvent() (*cloudevents.
 			Result:  f.result,
 			Message: msg,
 		},
+		Test: f.timeframe,
 		SyntheticExecution: SyntheticExecution{
-			BatchId:          f.executionData.BatchId,
-			ExecutionIds:     f.executionData.ExecutionIds,
-			FailedTriggers:   f.executionData.FailedTriggers,
-			FailedExecutions: f.executionData.FailedExecutions,
-			SuccessRate:      f.executionData.SuccessRate,
+			BatchId:               f.executionData.BatchId,
+			MonitorIds:            f.executionData.MonitorIds,
+			ExecutionIds:          f.executionData.ExecutionIds,
+			FailedTriggers:        f.executionData.FailedTriggers,
+			FailedExecutions:      f.executionData.FailedExecutions,
+			SuccessRate:           f.executionData.SuccessRate,
+			Executions:            f.executionData.Executions,
+			Attempts:              f.executionData.Attempts,
+			UrlOverrides:          f.executionData.UrlOverrides,
+			EphemeralMonitor:      f.ephemeralMonitor,
+			Reports:               f.reports,
+			Comparison:            f.comparison,
+			QuarantinedMonitorIds: f.quarantinedMonitorIds,
+			Flakiness:             f.flakiness,
 		},
 	}
 
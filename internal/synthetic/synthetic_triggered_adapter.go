@@ -5,6 +5,7 @@ import (
 
 	cloudevents "github.com/cloudevents/sdk-go/v2"
 	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
 	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 )
 
@@ -12,24 +13,88 @@ type SyntheticTriggerAdapterInterface interface {
 	adapter.EventContentAdapter
 	adapter.TriggeredCloudEventContentAdapter
 
-	GetSyntheticMonitorId() string
-	GetSyntheticMonitorTag() string
+	GetSyntheticMonitorIds() []string
+	GetSyntheticMonitorTags() []string
+	GetSyntheticMonitorNames() []string
+	IsAutomaticMonitorSelectionRequested() bool
+	IsLocalRunnerRequested() bool
 	IsWaitForDataRequested() bool
 	IsWaitForExecutionRequested() bool
+	GetWaitConfig() config.SyntheticWaitConfig
+	GetExecutionOptions() config.SyntheticExecutionOptions
+	GetLocations() []string
+	GetThresholds() *config.SyntheticThresholds
+	GetComparison() *config.SyntheticComparison
+	GetQuarantine() *config.SyntheticQuarantine
+	GetRetries() *int
+	GetEphemeralMonitor() *config.SyntheticMonitorDeclaration
+	GetEnableDisabledMonitors() *bool
+	GetCloudEvent() cloudevents.Event
 }
 
 type TestEventData struct {
-	MonitorTag string `json:"monitorTag"`
-	MonitorId  string `json:"monitorId"`
-	WaitFor    string `json:"waitFor"`
+	MonitorTag  string   `json:"monitorTag"`
+	MonitorTags []string `json:"monitorTags"`
+	MonitorId   string   `json:"monitorId"`
+	MonitorIds  []string `json:"monitorIds"`
+	// MonitorName and MonitorNames select monitors by name, names enclosed in slashes are regular expressions
+	MonitorName  string   `json:"monitorName"`
+	MonitorNames []string `json:"monitorNames"`
+	// MonitorSelector set to auto selects the monitors related to the applications and services tagged with the Keptn project, stage and service
+	MonitorSelector string                      `json:"monitorSelector"`
+	WaitFor         string                      `json:"waitFor"`
+	Locations       []string                    `json:"locations"`
+	Thresholds      *config.SyntheticThresholds `json:"thresholds"`
+	Retries         *int                        `json:"retries"`
+	// Runner set to local executes the HTTP checks declared in the synthetic-checks.yaml from within the service instead of triggering Dynatrace monitors
+	Runner string `json:"runner"`
+	// Comparison compares the results with the previous test of the service in the same stage
+	Comparison *config.SyntheticComparison `json:"comparison"`
+	// Quarantine excludes monitors from the result while still triggering and reporting them
+	Quarantine *config.SyntheticQuarantine `json:"quarantine"`
+	// EnableDisabledMonitors enables disabled monitors for the duration of the test
+	EnableDisabledMonitors *bool `json:"enableDisabledMonitors"`
+	// EphemeralMonitor is a template for an HTTP monitor created for this test only
+	EphemeralMonitor *config.SyntheticMonitorDeclaration `json:"ephemeralMonitor"`
+	config.SyntheticWaitConfig
+	config.SyntheticExecutionOptions
 }
 
 type SyntheticTriggerEventData struct {
 	keptnv2.EventData
-	MonitorTag string        `json:"monitorTag"`
-	MonitorId  string        `json:"monitorId"`
-	WaitFor    string        `json:"waitFor"`
-	Test       TestEventData `json:"test"`
+	MonitorTag  string   `json:"monitorTag"`
+	MonitorTags []string `json:"monitorTags"`
+	MonitorId   string   `json:"monitorId"`
+	MonitorIds  []string `json:"monitorIds"`
+	// MonitorName and MonitorNames select monitors by name, names enclosed in slashes are regular expressions
+	MonitorName  string   `json:"monitorName"`
+	MonitorNames []string `json:"monitorNames"`
+	// MonitorSelector set to auto selects the monitors related to the applications and services tagged with the Keptn project, stage and service
+	MonitorSelector string                      `json:"monitorSelector"`
+	WaitFor         string                      `json:"waitFor"`
+	Locations       []string                    `json:"locations"`
+	Thresholds      *config.SyntheticThresholds `json:"thresholds"`
+	Retries         *int                        `json:"retries"`
+	// Runner set to local executes the HTTP checks declared in the synthetic-checks.yaml from within the service instead of triggering Dynatrace monitors
+	Runner string `json:"runner"`
+	// Comparison compares the results with the previous test of the service in the same stage
+	Comparison *config.SyntheticComparison `json:"comparison"`
+	// Quarantine excludes monitors from the result while still triggering and reporting them
+	Quarantine *config.SyntheticQuarantine `json:"quarantine"`
+	// EnableDisabledMonitors enables disabled monitors for the duration of the test
+	EnableDisabledMonitors *bool `json:"enableDisabledMonitors"`
+	// EphemeralMonitor is a template for an HTTP monitor created for this test only
+	EphemeralMonitor *config.SyntheticMonitorDeclaration `json:"ephemeralMonitor"`
+	Test             TestEventData                       `json:"test"`
+	Deployment       TestTriggeredDeploymentData         `json:"deployment"`
+	config.SyntheticWaitConfig
+	config.SyntheticExecutionOptions
+}
+
+// TestTriggeredDeploymentData is the deployment the test is triggered for, as provided by the deployment finished event of the sequence
+type TestTriggeredDeploymentData struct {
+	keptnv2.TestTriggeredDeploymentDetails
+	DeploymentNames []string `json:"deploymentNames"`
 }
 
 // SyntheticTriggerAdapter is a content adaptor for events of type sh.keptn.event.test.triggered
@@ -84,9 +149,13 @@ func (a SyntheticTriggerAdapter) GetService() string {
 	return a.event.Service
 }
 
-// GetDeployment returns the name of the deployment
+// GetDeployment returns the name of the deployment, i.e. the first of the deployment names, or an empty string if there is none
 func (a SyntheticTriggerAdapter) GetDeployment() string {
-	return ""
+	if len(a.event.Deployment.DeploymentNames) == 0 {
+		return ""
+	}
+
+	return a.event.Deployment.DeploymentNames[0]
 }
 
 // GetTestStrategy returns the used test strategy
@@ -94,23 +163,71 @@ func (a SyntheticTriggerAdapter) GetTestStrategy() string {
 	return ""
 }
 
-// GetSyntheticMonitorId returns the used synthetic monitor id
-func (a SyntheticTriggerAdapter) GetSyntheticMonitorId() string {
-	isDefinedInTestAttribute := a.event.Test.MonitorId != ""
+// GetSyntheticMonitorIds returns the used synthetic monitor ids, combining monitorId and monitorIds
+func (a SyntheticTriggerAdapter) GetSyntheticMonitorIds() []string {
+	monitorIds := combineSingleAndMultipleValues(a.event.Test.MonitorId, a.event.Test.MonitorIds)
+	isDefinedInTestAttribute := len(monitorIds) > 0
+	if isDefinedInTestAttribute {
+		return monitorIds
+	} else {
+		return combineSingleAndMultipleValues(a.event.MonitorId, a.event.MonitorIds)
+	}
+}
+
+// GetSyntheticMonitorTags returns the used synthetic monitor tag expressions, combining monitorTag and monitorTags
+func (a SyntheticTriggerAdapter) GetSyntheticMonitorTags() []string {
+	monitorTags := combineSingleAndMultipleValues(a.event.Test.MonitorTag, a.event.Test.MonitorTags)
+	isDefinedInTestAttribute := len(monitorTags) > 0
+	if isDefinedInTestAttribute {
+		return monitorTags
+	} else {
+		return combineSingleAndMultipleValues(a.event.MonitorTag, a.event.MonitorTags)
+	}
+}
+
+// GetSyntheticMonitorNames returns the used synthetic monitor names or name patterns, combining monitorName and monitorNames
+func (a SyntheticTriggerAdapter) GetSyntheticMonitorNames() []string {
+	monitorNames := combineSingleAndMultipleValues(a.event.Test.MonitorName, a.event.Test.MonitorNames)
+	isDefinedInTestAttribute := len(monitorNames) > 0
+	if isDefinedInTestAttribute {
+		return monitorNames
+	} else {
+		return combineSingleAndMultipleValues(a.event.MonitorName, a.event.MonitorNames)
+	}
+}
+
+// IsAutomaticMonitorSelectionRequested returns whether the monitors related to the entities of the Keptn service shall be selected
+func (a SyntheticTriggerAdapter) IsAutomaticMonitorSelectionRequested() bool {
+	isDefinedInTestAttribute := a.event.Test.MonitorSelector != ""
 	if isDefinedInTestAttribute {
-		return a.event.Test.MonitorId
+		return strings.ToLower(a.event.Test.MonitorSelector) == "auto"
 	} else {
-		return a.event.MonitorId
+		return strings.ToLower(a.event.MonitorSelector) == "auto"
+	}
+}
+
+func combineSingleAndMultipleValues(value string, values []string) []string {
+	combinedValues := []string{}
+	if value != "" {
+		combinedValues = append(combinedValues, value)
+	}
+
+	for _, v := range values {
+		if v != "" {
+			combinedValues = append(combinedValues, v)
+		}
 	}
+
+	return combinedValues
 }
 
-// GetSyntheticMonitorTag returns the used synthetic monitor tag
-func (a SyntheticTriggerAdapter) GetSyntheticMonitorTag() string {
-	isDefinedInTestAttribute := a.event.Test.MonitorTag != ""
+// IsLocalRunnerRequested returns whether the HTTP checks shall be executed by the service itself
+func (a SyntheticTriggerAdapter) IsLocalRunnerRequested() bool {
+	isDefinedInTestAttribute := a.event.Test.Runner != ""
 	if isDefinedInTestAttribute {
-		return a.event.Test.MonitorTag
+		return strings.ToLower(a.event.Test.Runner) == "local"
 	} else {
-		return a.event.MonitorTag
+		return strings.ToLower(a.event.Runner) == "local"
 	}
 }
 
@@ -134,6 +251,104 @@ func (a SyntheticTriggerAdapter) IsWaitForExecutionRequested() bool {
 	}
 }
 
+// GetLocations returns the ids or names of the locations the synthetic monitors shall be executed from
+func (a SyntheticTriggerAdapter) GetLocations() []string {
+	isDefinedInTestAttribute := len(a.event.Test.Locations) > 0
+	if isDefinedInTestAttribute {
+		return a.event.Test.Locations
+	} else {
+		return a.event.Locations
+	}
+}
+
+// GetThresholds returns the thresholds used to determine the test result
+func (a SyntheticTriggerAdapter) GetThresholds() *config.SyntheticThresholds {
+	isDefinedInTestAttribute := a.event.Test.Thresholds != nil
+	if isDefinedInTestAttribute {
+		return a.event.Test.Thresholds
+	} else {
+		return a.event.Thresholds
+	}
+}
+
+// GetComparison returns how the results are compared with the previous test or nil if they are not compared
+func (a SyntheticTriggerAdapter) GetComparison() *config.SyntheticComparison {
+	isDefinedInTestAttribute := a.event.Test.Comparison != nil
+	if isDefinedInTestAttribute {
+		return a.event.Test.Comparison
+	} else {
+		return a.event.Comparison
+	}
+}
+
+// GetQuarantine returns the monitors excluded from the result or nil if none are quarantined
+func (a SyntheticTriggerAdapter) GetQuarantine() *config.SyntheticQuarantine {
+	isDefinedInTestAttribute := a.event.Test.Quarantine != nil
+	if isDefinedInTestAttribute {
+		return a.event.Test.Quarantine
+	} else {
+		return a.event.Quarantine
+	}
+}
+
+// GetRetries returns how often failed executions shall be re-triggered or nil if not defined
+func (a SyntheticTriggerAdapter) GetRetries() *int {
+	isDefinedInTestAttribute := a.event.Test.Retries != nil
+	if isDefinedInTestAttribute {
+		return a.event.Test.Retries
+	} else {
+		return a.event.Retries
+	}
+}
+
+// GetEnableDisabledMonitors returns whether disabled monitors shall be enabled for the duration of the test or nil if not defined
+func (a SyntheticTriggerAdapter) GetEnableDisabledMonitors() *bool {
+	isDefinedInTestAttribute := a.event.Test.EnableDisabledMonitors != nil
+	if isDefinedInTestAttribute {
+		return a.event.Test.EnableDisabledMonitors
+	} else {
+		return a.event.EnableDisabledMonitors
+	}
+}
+
+// GetEphemeralMonitor returns the template of the HTTP monitor created for this test only or nil if not defined
+func (a SyntheticTriggerAdapter) GetEphemeralMonitor() *config.SyntheticMonitorDeclaration {
+	isDefinedInTestAttribute := a.event.Test.EphemeralMonitor != nil
+	if isDefinedInTestAttribute {
+		return a.event.Test.EphemeralMonitor
+	} else {
+		return a.event.EphemeralMonitor
+	}
+}
+
+// GetExecutionOptions returns the options for the executions of the batch, preferring values defined in the test attribute
+func (a SyntheticTriggerAdapter) GetExecutionOptions() config.SyntheticExecutionOptions {
+	return overrideExecutionOptions(a.event.SyntheticExecutionOptions, a.event.Test.SyntheticExecutionOptions)
+}
+
+// GetWaitConfig returns the configuration for waiting for synthetic results, preferring values defined in the test attribute
+func (a SyntheticTriggerAdapter) GetWaitConfig() config.SyntheticWaitConfig {
+	waitConfig := a.event.SyntheticWaitConfig
+
+	if a.event.Test.WaitTimeout != "" {
+		waitConfig.WaitTimeout = a.event.Test.WaitTimeout
+	}
+
+	if a.event.Test.WaitInterval != "" {
+		waitConfig.WaitInterval = a.event.Test.WaitInterval
+	}
+
+	if a.event.Test.WaitBackoffFactor != 0 {
+		waitConfig.WaitBackoffFactor = a.event.Test.WaitBackoffFactor
+	}
+
+	if a.event.Test.WaitMaxInterval != "" {
+		waitConfig.WaitMaxInterval = a.event.Test.WaitMaxInterval
+	}
+
+	return waitConfig
+}
+
 // GetDeploymentStrategy returns the used deployment strategy
 func (a SyntheticTriggerAdapter) GetDeploymentStrategy() string {
 	return ""
@@ -147,3 +362,8 @@ func (a SyntheticTriggerAdapter) GetLabels() map[string]string {
 func (a SyntheticTriggerAdapter) GetEventID() string {
 	return a.cloudEvent.GetEventID()
 }
+
+// GetCloudEvent returns the test triggered cloud event
+func (a SyntheticTriggerAdapter) GetCloudEvent() cloudevents.Event {
+	return a.cloudEvent.GetCloudEvent()
+}
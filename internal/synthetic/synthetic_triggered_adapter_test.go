@@ -0,0 +1,69 @@
+package synthetic
+
+import (
+	"testing"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
+	"github.com/stretchr/testify/assert"
+)
+
+type dynatraceConfigReaderMock struct {
+	content string
+}
+
+func (m *dynatraceConfigReaderMock) GetDynatraceConfig(project string, stage string, service string) (string, error) {
+	return m.content, nil
+}
+
+func TestSyntheticTriggerAdapter_GetDeployment(t *testing.T) {
+	triggerAdapter := createTestSyntheticTriggerAdapter(t, map[string]interface{}{
+		"deployment": map[string]interface{}{
+			"deploymentNames":      []string{"canary"},
+			"deploymentURIsPublic": []string{"http://frontend.staging.example.com"},
+		},
+	})
+	assert.Equal(t, "canary", triggerAdapter.GetDeployment())
+
+	triggerAdapter = createTestSyntheticTriggerAdapter(t, map[string]interface{}{})
+	assert.Equal(t, "", triggerAdapter.GetDeployment())
+}
+
+// TestSyntheticTriggerAdapter_BaseUrlWithDeployment tests that the deployment placeholder of the base URL is replaced by the deployment of the test triggered event.
+func TestSyntheticTriggerAdapter_BaseUrlWithDeployment(t *testing.T) {
+	triggerAdapter := createTestSyntheticTriggerAdapter(t, map[string]interface{}{
+		"deployment": map[string]interface{}{
+			"deploymentNames": []string{"canary"},
+		},
+	})
+
+	dynatraceConfigGetter := config.NewDynatraceConfigGetter(&dynatraceConfigReaderMock{content: `
+spec_version: '0.1.0'
+synthetic:
+  baseUrl: "https://$DEPLOYMENT.example.com"
+`})
+
+	dynatraceConfig, err := dynatraceConfigGetter.GetDynatraceConfig(triggerAdapter)
+	assert.NoError(t, err)
+	if assert.NotNil(t, dynatraceConfig.Synthetic) {
+		assert.Equal(t, "https://canary.example.com", dynatraceConfig.Synthetic.BaseUrl)
+	}
+}
+
+func createTestSyntheticTriggerAdapter(t *testing.T, data map[string]interface{}) *SyntheticTriggerAdapter {
+	event := cloudevents.NewEvent()
+	event.SetID("triggered-1")
+	event.SetType("sh.keptn.event.test.triggered")
+	event.SetSource("shipyard-controller")
+	event.SetExtension("shkeptncontext", "context-1")
+
+	data["project"] = "easytravel"
+	data["stage"] = "staging"
+	data["service"] = "frontend"
+	err := event.SetData(cloudevents.ApplicationJSON, data)
+	assert.NoError(t, err)
+
+	triggerAdapter, err := NewSyntheticTriggerAdapterFromEvent(event)
+	assert.NoError(t, err)
+	return triggerAdapter
+}
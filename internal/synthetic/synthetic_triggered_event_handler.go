@@ -2,46 +2,88 @@ package synthetic
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
 
 	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/config"
 	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
 	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
 	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
 	log "github.com/sirupsen/logrus"
 )
 
 // SyntheticTriggerEventHandler handles a test triggered event.
+// Everything done besides triggering and evaluating the monitors, e.g. cleaning up or reporting the results in addition to the finished event, is best effort:
+// failing to do it is logged but does not fail the test. Cleaning up and reporting use replyCtx, so that they are also done if workCtx is cancelled, e.g. on shutdown.
 type SyntheticTriggerEventHandler struct {
 	event       SyntheticTriggerAdapterInterface
 	dtClient    dynatrace.ClientInterface
 	sClient     connector.SyntheticConnectorInterface
 	kClient     keptn.ClientInterface
 	eClient     keptn.EventClientInterface
+	rClient     keptn.SyntheticResourcesClientInterface
 	attachRules *dynatrace.AttachRules
+	synthetic   *config.SyntheticConfig
+
+	// ephemeralMonitor is the monitor created for this test only, if any
+	ephemeralMonitor *EphemeralMonitor
+
+	// enabledMonitorIds are the disabled monitors enabled for this test, which are disabled again once it is finished
+	enabledMonitorIds []string
+
+	// startTime is the time the first batch was triggered if its execution is waited for, i.e. the start of the test timeframe
+	startTime time.Time
+
+	// batchStates persists the state of the batch waited for, so that waiting can be resumed after a restart, or is nil if the state is not persisted
+	batchStates       BatchStateStore
+	batchState        *BatchState
+	isBatchStateSaved bool
+
+	// isSuspended is set if waiting was interrupted by a shutdown and is resumed after the restart
+	isSuspended bool
+
+	// runningTests allows the test to be aborted by an event of its Keptn context, runningTest is this test once it is running
+	runningTests *RunningTests
+	runningTest  *runningTest
 }
 
 // NewSyntheticTriggerEventHandler creates a new SyntheticTriggerEventHandler.
-func NewSyntheticTriggerEventHandler(event SyntheticTriggerAdapterInterface, dtClient dynatrace.ClientInterface, sClient connector.SyntheticConnectorInterface, kClient keptn.ClientInterface, eClient keptn.EventClientInterface, attachRules *dynatrace.AttachRules) *SyntheticTriggerEventHandler {
+func NewSyntheticTriggerEventHandler(event SyntheticTriggerAdapterInterface, dtClient dynatrace.ClientInterface, sClient connector.SyntheticConnectorInterface, kClient keptn.ClientInterface, eClient keptn.EventClientInterface, rClient keptn.SyntheticResourcesClientInterface, batchStates BatchStateStore, runningTests *RunningTests, attachRules *dynatrace.AttachRules, synthetic *config.SyntheticConfig) *SyntheticTriggerEventHandler {
 	return &SyntheticTriggerEventHandler{
-		event:       event,
-		dtClient:    dtClient,
-		sClient:     sClient,
-		kClient:     kClient,
-		eClient:     eClient,
-		attachRules: attachRules,
+		event:        event,
+		dtClient:     dtClient,
+		sClient:      sClient,
+		kClient:      kClient,
+		eClient:      eClient,
+		rClient:      rClient,
+		batchStates:  batchStates,
+		runningTests: runningTests,
+		attachRules:  attachRules,
+		synthetic:    synthetic,
 	}
 }
 
 // HandleEvent handles a test triggered event.
 func (eh *SyntheticTriggerEventHandler) HandleEvent(workCtx context.Context, replyCtx context.Context) error {
-	syntheticMonitorId := eh.event.GetSyntheticMonitorId()
-	syntheticMonitorTag := eh.event.GetSyntheticMonitorTag()
+	if eh.event.IsLocalRunnerRequested() {
+		return eh.handleLocalChecks(workCtx, replyCtx)
+	}
 
-	isMonitorIdDefined := syntheticMonitorId != ""
-	isMonitorTagDefined := syntheticMonitorTag != ""
+	selection := connector.MonitorSelection{
+		MonitorIds:   eh.event.GetSyntheticMonitorIds(),
+		MonitorTags:  eh.event.GetSyntheticMonitorTags(),
+		MonitorNames: eh.event.GetSyntheticMonitorNames(),
+	}
 
-	if !isMonitorIdDefined && !isMonitorTagDefined {
-		log.Info("Neither monitor id nor tag provided. Skipping handler...")
+	ephemeralMonitorTemplate := eh.event.GetEphemeralMonitor()
+	monitorsConfig, monitorsConfigErr := eh.getSyntheticMonitorsConfig()
+	isAutomaticMonitorSelectionRequested := eh.event.IsAutomaticMonitorSelectionRequested()
+	if selection.IsEmpty() && !isAutomaticMonitorSelectionRequested && ephemeralMonitorTemplate == nil && monitorsConfig == nil && monitorsConfigErr == nil {
+		log.Info("Neither monitor id, tag nor name provided nor monitors declared. Skipping handler...")
 		return nil
 	}
 
@@ -50,75 +92,571 @@ func (eh *SyntheticTriggerEventHandler) HandleEvent(workCtx context.Context, rep
 		return err
 	}
 
-	sClient := connector.NewSyntheticConnector(eh.dtClient)
+	workCtx, stopRunning := eh.startRunning(workCtx)
+	defer stopRunning()
+
+	// cleaning up is usually done before the finished event is sent, this only covers paths not sending one
+	defer eh.cleanUp(replyCtx)
 
 	executionData := connector.ExecutionData{}
 
-	if isMonitorTagDefined {
-		executionData, err = sClient.TriggerByTag(workCtx, syntheticMonitorTag)
+	if monitorsConfigErr != nil {
+		eh.sendFailedTriggerSyntheticFinishedEvent(replyCtx, executionData, monitorsConfigErr)
+		return nil
+	}
+
+	// declared monitors are synced before triggering and always part of the batch
+	if monitorsConfig != nil {
+		syncedMonitorIds, err := syncSyntheticMonitors(workCtx, dynatrace.NewSyntheticMonitorsClient(eh.dtClient), monitorsConfig, eh.event.GetProject(), eh.event.GetStage(), eh.event.GetService())
+		if err != nil {
+			eh.sendFailedTriggerSyntheticFinishedEvent(replyCtx, executionData, err)
+			return nil
+		}
+
+		selection.MonitorIds = append(selection.MonitorIds, syncedMonitorIds...)
+	}
+
+	if isAutomaticMonitorSelectionRequested {
+		relatedMonitorIds, err := eh.sClient.FindRelatedMonitors(workCtx, eh.event.GetProject(), eh.event.GetStage(), eh.event.GetService())
 		if err != nil {
-			eh.sendFailedTriggerSyntheticFinishedEvent(executionData, err)
+			eh.sendFailedTriggerSyntheticFinishedEvent(replyCtx, executionData, err)
 			return nil
 		}
-	} else {
-		executionData, err = sClient.TriggerById(workCtx, syntheticMonitorId)
+
+		selection.MonitorIds = append(selection.MonitorIds, relatedMonitorIds...)
+	}
+
+	if ephemeralMonitorTemplate != nil {
+		eh.ephemeralMonitor, err = createEphemeralMonitor(workCtx, dynatrace.NewSyntheticMonitorsClient(eh.dtClient), *ephemeralMonitorTemplate, eh.event)
 		if err != nil {
-			eh.sendFailedTriggerSyntheticFinishedEvent(executionData, err)
+			eh.sendFailedTriggerSyntheticFinishedEvent(replyCtx, executionData, err)
 			return nil
 		}
+
+		selection.MonitorIds = append(selection.MonitorIds, eh.ephemeralMonitor.CreatedMonitorId)
 	}
 
-	isWaitForExecutionRequested := eh.event.IsWaitForExecutionRequested()
-	// isWaitForDataRequested := eh.event.IsWaitForDataRequested()
+	if selection.IsEmpty() {
+		eh.sendFailedTriggerSyntheticFinishedEvent(replyCtx, executionData, errors.New("no synthetic monitors are declared"))
+		return nil
+	}
 
-	if isWaitForExecutionRequested {
-		batchResponseBody, successRate, err := sClient.WaitForBatchExecution(workCtx)
+	executionOptions, err := eh.getExecutionOptions()
+	if err != nil {
+		eh.sendFailedTriggerSyntheticFinishedEvent(replyCtx, executionData, err)
+		return nil
+	}
+
+	err = eh.runPreflightCheck(workCtx, selection)
+	if err != nil {
+		eh.sendFailedTriggerSyntheticFinishedEvent(replyCtx, executionData, err)
+		return nil
+	}
+
+	// an ephemeral monitor is deleted and temporarily enabled monitors are disabled once the test is finished, so their execution is always awaited
+	isWaitForExecutionRequested := eh.event.IsWaitForExecutionRequested() || eh.ephemeralMonitor != nil || len(eh.enabledMonitorIds) > 0
+	isWaitForDataRequested := eh.event.IsWaitForDataRequested()
+
+	// get the polling policy before triggering to fail fast on an invalid configuration
+	pollingPolicy := connector.PollingPolicy{}
+	if isWaitForExecutionRequested || isWaitForDataRequested {
+		pollingPolicy, err = eh.getPollingPolicy()
 		if err != nil {
-			eh.sendWarningfulTriggerSyntheticFinishedEvent(executionData, err)
-			return err
+			eh.sendFailedTriggerSyntheticFinishedEvent(replyCtx, executionData, err)
+			return nil
 		}
+	}
 
-		executionData.FailedExecutions = batchResponseBody.FailedExecutions
-		executionData.SuccessRate = successRate
+	locations := eh.getLocations()
+
+	batch, err := eh.sClient.Trigger(workCtx, selection, locations, executionOptions)
+	if err != nil {
+		eh.sendFailedTriggerSyntheticFinishedEvent(replyCtx, executionData, err)
+		return nil
+	}
+
+	executionData = batch.GetExecutionData()
+	eh.sendBatchStartedEvent(workCtx, executionData)
+
+	// waiting for data implies waiting for the execution, as data is only available once the batch has been executed
+	if !isWaitForExecutionRequested && !isWaitForDataRequested {
+		return eh.sendSuccessfulTriggerSyntheticFinishedEvent(replyCtx, executionData, false)
+	}
+
+	eh.startTime = batch.GetState().TriggerTime
+	eh.batchState = &BatchState{
+		KeptnContext:     eh.event.GetShKeptnContext(),
+		TriggeredEventId: eh.event.GetEventID(),
+		StartTime:        eh.startTime,
+		Event:            eh.event.GetCloudEvent(),
+	}
+	eh.saveBatchState(workCtx, batch, nil)
+
+	return eh.waitForBatch(workCtx, replyCtx, batch, nil, pollingPolicy, isWaitForDataRequested)
+}
+
+// handleLocalChecks executes the HTTP checks declared in the synthetic-checks.yaml from within the service, reports them as third-party synthetic monitors and sends the finished event.
+// As the checks are executed synchronously, they are neither persisted nor resumed after a restart.
+func (eh *SyntheticTriggerEventHandler) handleLocalChecks(workCtx context.Context, replyCtx context.Context) error {
+	err := eh.sendTriggerSyntheticStartedEvent()
+	if err != nil {
+		return err
+	}
+
+	workCtx, stopRunning := eh.startRunning(workCtx)
+	defer stopRunning()
+
+	executionData := connector.ExecutionData{}
+
+	checksConfig, err := config.NewSyntheticChecksConfigGetter(eh.rClient).GetSyntheticChecksConfig(eh.event)
+	if err != nil {
+		eh.sendFailedTriggerSyntheticFinishedEvent(replyCtx, executionData, fmt.Errorf("could not get synthetic checks: %w", err))
+		return nil
+	}
+
+	eh.startTime = time.Now()
+	executionData.BatchId = localBatchIdPrefix + eh.event.GetShKeptnContext()
+	for _, check := range checksConfig.Checks {
+		executionData.MonitorIds = append(executionData.MonitorIds, getExternalTestID(eh.event, localRunnerEngineName, check.Name))
+	}
+	eh.sendBatchStartedEvent(workCtx, executionData)
+
+	results := runLocalChecks(workCtx, &http.Client{Timeout: localCheckTimeout}, checksConfig.Checks, localCheckRetryDelay)
+	executionData = newLocalExecutionData(eh.event, checksConfig.Location, results)
+
+	if reason := eh.getAbortReason(); reason != "" {
+		return eh.sendAbortedTriggerSyntheticFinishedEvent(replyCtx, executionData, reason)
+	}
+
+	tests, err := newThirdPartySyntheticTests(workCtx, eh.event, newLocalExternalTestResults(checksConfig.Location, results), eh.startTime, time.Now())
+	if err == nil {
+		err = dynatrace.NewThirdPartySyntheticClient(eh.dtClient).Push(workCtx, tests)
+	}
+
+	if err != nil {
+		eh.sendWarningfulTriggerSyntheticFinishedEvent(replyCtx, executionData, err)
+		return err
+	}
+
+	return eh.sendSuccessfulTriggerSyntheticFinishedEvent(replyCtx, executionData, true)
+}
+
+// ResumeBatch resumes waiting for the batch of a test which was interrupted, e.g. by a restart of the service, and sends the finished event of the test.
+func (eh *SyntheticTriggerEventHandler) ResumeBatch(workCtx context.Context, replyCtx context.Context, state BatchState) error {
+	eh.batchState = &state
+	eh.isBatchStateSaved = true
+	eh.startTime = state.StartTime
+	eh.ephemeralMonitor = state.EphemeralMonitor
+	eh.enabledMonitorIds = state.EnabledMonitorIds
+
+	workCtx, stopRunning := eh.startRunning(workCtx)
+	defer stopRunning()
+
+	// cleaning up is usually done before the finished event is sent, this only covers paths not sending one
+	defer eh.cleanUp(replyCtx)
+
+	log.WithFields(log.Fields{"keptnContext": state.KeptnContext, "batchId": state.Batch.ExecutionData.BatchId}).Info("Resuming synthetic batch")
+
+	batch := eh.sClient.RestoreBatch(state.Batch)
+
+	pollingPolicy, err := eh.getPollingPolicy()
+	if err != nil {
+		eh.sendFailedTriggerSyntheticFinishedEvent(replyCtx, mergeExecutionAttempts(append(state.Attempts, state.Batch.ExecutionData)), err)
+		return nil
+	}
+
+	return eh.waitForBatch(workCtx, replyCtx, batch, state.Attempts, pollingPolicy, eh.event.IsWaitForDataRequested())
+}
+
+// waitForBatch waits for the execution of the batch, re-triggers failed executions and sends the finished event.
+// attempts are the finished attempts preceding the batch, e.g. of a resumed test.
+func (eh *SyntheticTriggerEventHandler) waitForBatch(workCtx context.Context, replyCtx context.Context, batch connector.Batch, attempts []connector.ExecutionData, pollingPolicy connector.PollingPolicy, isWaitForDataRequested bool) error {
+	executionData, err := waitForExecution(workCtx, batch, pollingPolicy)
+	if err != nil {
+		return eh.handleWaitError(workCtx, replyCtx, mergeExecutionAttempts(append(attempts, executionData)), err)
+	}
+
+	attempts = append(attempts, executionData)
+	for retry := len(attempts); retry <= eh.getRetries(); retry++ {
+		failedMonitorLocations := getFailedMonitorLocations(attempts[len(attempts)-1])
+		if len(failedMonitorLocations) == 0 {
+			break
+		}
+
+		log.WithField("retry", retry).Infof("Re-triggering %d failed monitor and location pairs", len(failedMonitorLocations))
+
+		retryExecutionData := connector.ExecutionData{}
+		retryBatch, err := batch.Retrigger(workCtx, failedMonitorLocations)
+		if err == nil {
+			batch = retryBatch
+			eh.saveBatchState(workCtx, batch, attempts)
+			retryExecutionData, err = waitForExecution(workCtx, batch, pollingPolicy)
+		}
 
-		_, err = sClient.IngestSyntheticSuccessMetric(workCtx, syntheticMonitorId, eh.event.GetProject(), eh.event.GetService(), eh.event.GetStage(), executionData.BatchId, successRate)
 		if err != nil {
-			eh.sendWarningfulTriggerSyntheticFinishedEvent(executionData, err)
-			return err
+			return eh.handleWaitError(workCtx, replyCtx, mergeExecutionAttempts(append(attempts, retryExecutionData)), err)
 		}
 
-		// } else if isWaitForDataRequested {
-		// 	err = eh.sendSuccessfulTriggerSyntheticFinishedEvent(executionData)
-		// 	if err != nil {
-		//    eh.sendFailedTriggerSyntheticFinishedEvent(executionData, err)
-		// 		return err
-		// 	}
+		attempts = append(attempts, retryExecutionData)
+	}
+
+	executionData = mergeExecutionAttempts(attempts)
+
+	_, err = eh.sClient.IngestSyntheticMetrics(workCtx, executionData, eh.event.GetProject(), eh.event.GetService(), eh.event.GetStage())
+	if err != nil {
+		eh.sendWarningfulTriggerSyntheticFinishedEvent(replyCtx, executionData, err)
+		return err
+	}
+
+	if isWaitForDataRequested {
+		err = eh.sClient.WaitForData(workCtx, executionData.MonitorIds, eh.startTime, pollingPolicy)
+		if err != nil {
+			return eh.handleWaitError(workCtx, replyCtx, executionData, err)
+		}
+	}
+
+	err = eh.sendSuccessfulTriggerSyntheticFinishedEvent(replyCtx, executionData, true)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// handleWaitError sends a warning or, if the test was aborted, an aborted finished event, unless waiting was interrupted by a shutdown and the state of the batch was saved.
+// In this case, neither the finished event is sent nor cleaning up is done, as waiting for the batch is resumed after the restart.
+func (eh *SyntheticTriggerEventHandler) handleWaitError(workCtx context.Context, replyCtx context.Context, executionData connector.ExecutionData, err error) error {
+	if workCtx.Err() != nil && eh.isBatchStateSaved && !eh.runningTest.isAbortedByEvent() {
+		log.WithError(err).WithField("batchId", eh.batchState.Batch.ExecutionData.BatchId).Warn("Stopped waiting for synthetic batch, waiting is resumed after the restart")
+		eh.isSuspended = true
+		return err
+	}
+
+	eh.sendWarningfulTriggerSyntheticFinishedEvent(replyCtx, executionData, err)
+	return err
+}
+
+// waitForExecution waits for the execution of the batch and returns its execution data including failed executions, success rate and execution reports.
+func waitForExecution(workCtx context.Context, batch connector.Batch, pollingPolicy connector.PollingPolicy) (connector.ExecutionData, error) {
+	executionData := batch.GetExecutionData()
+
+	batchResponseBody, successRate, err := batch.Wait(workCtx, pollingPolicy)
+	if err != nil {
+		return executionData, err
+	}
+
+	executionData.FailedExecutions = batchResponseBody.FailedExecutions
+	executionData.SuccessRate = successRate
 
-		// 	return nil
+	executionData.Executions, err = batch.Report(workCtx)
+	if err != nil {
+		return executionData, err
+	}
+
+	return executionData, nil
+}
+
+// runPreflightCheck checks that the selected monitors exist, are enabled and have locations, so that the test fails fast with a descriptive message.
+// If requested, disabled monitors are enabled for the duration of the test.
+func (eh *SyntheticTriggerEventHandler) runPreflightCheck(workCtx context.Context, selection connector.MonitorSelection) error {
+	monitors, err := eh.sClient.ResolveMonitors(workCtx, selection)
+	if err != nil {
+		return err
 	}
 
-	err = eh.sendSuccessfulTriggerSyntheticFinishedEvent(executionData)
+	disabledMonitorIds, err := connector.CheckMonitors(monitors, eh.isEnableDisabledMonitorsRequested())
 	if err != nil {
 		return err
 	}
 
+	monitorsClient := dynatrace.NewSyntheticMonitorsClient(eh.dtClient)
+	for _, monitorId := range disabledMonitorIds {
+		err = monitorsClient.SetEnabled(workCtx, monitorId, true)
+		if err != nil {
+			return fmt.Errorf("could not enable disabled synthetic monitor %s: %w", monitorId, err)
+		}
+
+		log.WithField("monitorId", monitorId).Info("Enabled disabled synthetic monitor for the duration of the test")
+		eh.enabledMonitorIds = append(eh.enabledMonitorIds, monitorId)
+	}
+
 	return nil
 }
 
+// isEnableDisabledMonitorsRequested checks whether disabled monitors shall be enabled as defined in the event or, if not defined, in the dynatrace.conf.yaml.
+func (eh *SyntheticTriggerEventHandler) isEnableDisabledMonitorsRequested() bool {
+	enableDisabledMonitors := eh.event.GetEnableDisabledMonitors()
+	if enableDisabledMonitors == nil && eh.synthetic != nil {
+		enableDisabledMonitors = eh.synthetic.EnableDisabledMonitors
+	}
+
+	return enableDisabledMonitors != nil && *enableDisabledMonitors
+}
+
+// getSyntheticMonitorsConfig gets the monitors declared in the synthetic.yaml or nil if there is none.
+func (eh *SyntheticTriggerEventHandler) getSyntheticMonitorsConfig() (*config.SyntheticMonitorsConfig, error) {
+	monitorsConfig, err := config.NewSyntheticMonitorsConfigGetter(eh.rClient).GetSyntheticMonitorsConfig(eh.event)
+	if err != nil {
+		var rnfErr *keptn.ResourceNotFoundError
+		if errors.As(err, &rnfErr) {
+			return nil, nil
+		}
+
+		return nil, err
+	}
+
+	return monitorsConfig, nil
+}
+
+// getRetries gets the number of retries defined in the event or, if not defined, in the dynatrace.conf.yaml. Failed executions are not re-triggered by default.
+func (eh *SyntheticTriggerEventHandler) getRetries() int {
+	retries := eh.event.GetRetries()
+	if retries == nil && eh.synthetic != nil {
+		retries = eh.synthetic.Retries
+	}
+
+	if retries == nil || *retries < 0 {
+		return 0
+	}
+
+	return *retries
+}
+
+// getLocations gets the locations defined in the event or, if none are defined, in the dynatrace.conf.yaml.
+func (eh *SyntheticTriggerEventHandler) getLocations() []string {
+	locations := eh.event.GetLocations()
+	if len(locations) > 0 || eh.synthetic == nil {
+		return locations
+	}
+
+	return eh.synthetic.Locations
+}
+
+// getThresholds gets the thresholds defined in the event or, if none are defined, in the dynatrace.conf.yaml.
+func (eh *SyntheticTriggerEventHandler) getThresholds() *config.SyntheticThresholds {
+	thresholds := eh.event.GetThresholds()
+	if thresholds != nil || eh.synthetic == nil {
+		return thresholds
+	}
+
+	return eh.synthetic.Thresholds
+}
+
+// getComparison gets the comparison with the previous test defined in the event or, if none is defined, in the dynatrace.conf.yaml.
+func (eh *SyntheticTriggerEventHandler) getComparison() *config.SyntheticComparison {
+	comparison := eh.event.GetComparison()
+	if comparison != nil || eh.synthetic == nil {
+		return comparison
+	}
+
+	return eh.synthetic.Comparison
+}
+
+// getQuarantine gets the quarantined monitors defined in the event or, if none are defined, in the dynatrace.conf.yaml.
+func (eh *SyntheticTriggerEventHandler) getQuarantine() *config.SyntheticQuarantine {
+	quarantine := eh.event.GetQuarantine()
+	if quarantine != nil || eh.synthetic == nil {
+		return quarantine
+	}
+
+	return eh.synthetic.Quarantine
+}
+
+// getPollingPolicy gets the polling policy based on the defaults, the dynatrace.conf.yaml and the event, in that order.
+func (eh *SyntheticTriggerEventHandler) getPollingPolicy() (connector.PollingPolicy, error) {
+	if eh.synthetic == nil {
+		return newPollingPolicy(eh.event.GetWaitConfig())
+	}
+
+	return newPollingPolicy(eh.synthetic.SyntheticWaitConfig, eh.event.GetWaitConfig())
+}
+
+// getExecutionOptions gets the execution options defined in the dynatrace.conf.yaml, overridden by the options defined in the event.
+func (eh *SyntheticTriggerEventHandler) getExecutionOptions() (connector.ExecutionOptions, error) {
+	if eh.synthetic == nil {
+		return newExecutionOptions(eh.event.GetExecutionOptions())
+	}
+
+	return newExecutionOptions(eh.synthetic.SyntheticExecutionOptions, eh.event.GetExecutionOptions())
+}
+
+// getTestTimeframe gets the timeframe of the test or nil if the execution of the batch was not waited for, as there is no meaningful end of the test in this case.
+func (eh *SyntheticTriggerEventHandler) getTestTimeframe(executionData connector.ExecutionData) *SyntheticTestTimeframe {
+	if eh.startTime.IsZero() {
+		return nil
+	}
+
+	return newTestTimeframe(eh.startTime, executionData, time.Now())
+}
+
 func (eh *SyntheticTriggerEventHandler) sendTriggerSyntheticStartedEvent() error {
 	return eh.sendEvent(NewSyntheticTriggerStartedEventFactory(eh.event))
 }
 
-func (eh *SyntheticTriggerEventHandler) sendSuccessfulTriggerSyntheticFinishedEvent(executionData connector.ExecutionData) error {
-	return eh.sendEvent(NewSucceededSyntheticTriggerFinishedEventFactory(eh.event, executionData, nil))
+// sendSuccessfulTriggerSyntheticFinishedEvent sends a succeeded finished event with the result of evaluating the results against the thresholds, which becomes a warning if the results regressed compared to the previous test.
+// Quarantined monitors are excluded from the evaluation and the comparison. The success rate and failed executions are only evaluated if isExecutionAvailable is true, i.e. if the service waited for the execution.
+func (eh *SyntheticTriggerEventHandler) sendSuccessfulTriggerSyntheticFinishedEvent(replyCtx context.Context, executionData connector.ExecutionData, isExecutionAvailable bool) error {
+	flakiness := eh.updateMonitorHistory(executionData)
+	quarantinedMonitorIds := eh.getQuarantinedMonitorIds(executionData, flakiness)
+	evaluatedData := excludeQuarantinedMonitors(executionData, quarantinedMonitorIds)
+
+	comparison := eh.compareWithPreviousTest(evaluatedData)
+	evaluation := evaluateResult(evaluatedData, eh.getThresholds(), isExecutionAvailable).withComparison(comparison)
+
+	var err error
+	if message := evaluation.message(); message != "" {
+		err = errors.New(message)
+	}
+
+	eh.cleanUp(replyCtx)
+	eh.sendBatchFinishedEvent(replyCtx, executionData, evaluation.result)
+	timeframe := eh.getTestTimeframe(executionData)
+	reports := eh.uploadReports(executionData, timeframe, evaluation.result, err)
+	return eh.sendFinishedEvent(replyCtx, NewSucceededSyntheticTriggerFinishedEventFactory(eh.event, executionData, eh.ephemeralMonitor, timeframe, reports, comparison, flakiness, quarantinedMonitorIds, evaluation.result, err))
+}
+
+func (eh *SyntheticTriggerEventHandler) sendWarningfulTriggerSyntheticFinishedEvent(replyCtx context.Context, executionData connector.ExecutionData, err error) error {
+	if reason := eh.getAbortReason(); reason != "" {
+		return eh.sendAbortedTriggerSyntheticFinishedEvent(replyCtx, executionData, reason)
+	}
+
+	eh.cleanUp(replyCtx)
+	eh.sendBatchFinishedEvent(replyCtx, executionData, keptnv2.ResultWarning)
+	timeframe := eh.getTestTimeframe(executionData)
+	reports := eh.uploadReports(executionData, timeframe, keptnv2.ResultWarning, err)
+	return eh.sendFinishedEvent(replyCtx, NewWarningSyntheticTriggerFinishedEventFactory(eh.event, executionData, eh.ephemeralMonitor, timeframe, reports, err))
+}
+
+func (eh *SyntheticTriggerEventHandler) sendFailedTriggerSyntheticFinishedEvent(replyCtx context.Context, executionData connector.ExecutionData, err error) error {
+	if reason := eh.getAbortReason(); reason != "" {
+		return eh.sendAbortedTriggerSyntheticFinishedEvent(replyCtx, executionData, reason)
+	}
+
+	eh.cleanUp(replyCtx)
+	eh.sendBatchFinishedEvent(replyCtx, executionData, keptnv2.ResultFailed)
+	timeframe := eh.getTestTimeframe(executionData)
+	reports := eh.uploadReports(executionData, timeframe, keptnv2.ResultFailed, err)
+	return eh.sendFinishedEvent(replyCtx, NewErroredSyntheticTriggerFinishedEventFactory(eh.event, executionData, eh.ephemeralMonitor, timeframe, reports, err))
+}
+
+// sendAbortedTriggerSyntheticFinishedEvent sends an aborted finished event including the results collected so far.
+func (eh *SyntheticTriggerEventHandler) sendAbortedTriggerSyntheticFinishedEvent(replyCtx context.Context, executionData connector.ExecutionData, reason string) error {
+	log.WithFields(log.Fields{"batchId": executionData.BatchId, "reason": reason}).Info("Aborted synthetic test")
+
+	eh.cleanUp(replyCtx)
+	err := fmt.Errorf("synthetic test aborted: %s", reason)
+	eh.sendBatchFinishedEvent(replyCtx, executionData, keptnv2.ResultWarning)
+	timeframe := eh.getTestTimeframe(executionData)
+	reports := eh.uploadReports(executionData, timeframe, keptnv2.ResultWarning, err)
+	return eh.sendFinishedEvent(replyCtx, NewAbortedSyntheticTriggerFinishedEventFactory(eh.event, executionData, eh.ephemeralMonitor, timeframe, reports, err))
+}
+
+// startRunning adds the test to the running tests, so that it can be aborted by an event of its Keptn context.
+// The returned context is done once the test is aborted or workCtx is done, the returned function has to be called once the test is finished.
+func (eh *SyntheticTriggerEventHandler) startRunning(workCtx context.Context) (context.Context, func()) {
+	if eh.runningTests == nil {
+		eh.runningTest = newRunningTest(workCtx)
+		return eh.runningTest.ctx, eh.runningTest.cancel
+	}
+
+	var stopRunning func()
+	eh.runningTest, stopRunning = eh.runningTests.add(workCtx, eh.event.GetShKeptnContext())
+	return eh.runningTest.ctx, stopRunning
+}
+
+// getAbortReason returns why the test was aborted, i.e. by an event or a shutdown, or an empty string if it was not aborted.
+func (eh *SyntheticTriggerEventHandler) getAbortReason() string {
+	if eh.runningTest == nil {
+		return ""
+	}
+
+	return eh.runningTest.getAbortReason()
+}
+
+// cleanUp disables the monitors enabled for this test and deletes the monitor created for this test, so that the finished event can report its deletion.
+func (eh *SyntheticTriggerEventHandler) cleanUp(replyCtx context.Context) {
+	if eh.isSuspended {
+		return
+	}
+
+	cleanUpMonitors(replyCtx, dynatrace.NewSyntheticMonitorsClient(eh.dtClient), eh.enabledMonitorIds, eh.ephemeralMonitor)
+	eh.enabledMonitorIds = nil
+	eh.saveCleanedUpBatchState(replyCtx)
+}
+
+// cleanUpMonitors disables the monitors enabled for a test and deletes the monitor created for it.
+func cleanUpMonitors(replyCtx context.Context, monitorsClient *dynatrace.SyntheticMonitorsClient, enabledMonitorIds []string, ephemeralMonitor *EphemeralMonitor) {
+	for _, monitorId := range enabledMonitorIds {
+		err := monitorsClient.SetEnabled(replyCtx, monitorId, false)
+		if err != nil {
+			log.WithError(err).WithField("monitorId", monitorId).Error("Could not disable synthetic monitor enabled for the test")
+			continue
+		}
+
+		log.WithField("monitorId", monitorId).Info("Disabled synthetic monitor enabled for the test")
+	}
+
+	deleteEphemeralMonitor(replyCtx, monitorsClient, ephemeralMonitor)
 }
 
-func (eh *SyntheticTriggerEventHandler) sendWarningfulTriggerSyntheticFinishedEvent(executionData connector.ExecutionData, err error) error {
-	return eh.sendEvent(NewWarningSyntheticTriggerFinishedEventFactory(eh.event, executionData, err))
+// saveCleanedUpBatchState saves the state after cleaning up, so that a test resumed because its finished event could not be sent reports the deleted monitor instead of deleting it again.
+func (eh *SyntheticTriggerEventHandler) saveCleanedUpBatchState(replyCtx context.Context) {
+	if eh.batchStates == nil || !eh.isBatchStateSaved {
+		return
+	}
+
+	eh.batchState.EphemeralMonitor = eh.ephemeralMonitor
+	eh.batchState.EnabledMonitorIds = eh.enabledMonitorIds
+
+	err := eh.batchStates.Save(replyCtx, *eh.batchState)
+	if err != nil {
+		log.WithError(err).Error("Could not save state of cleaned up synthetic batch")
+	}
 }
 
-func (eh *SyntheticTriggerEventHandler) sendFailedTriggerSyntheticFinishedEvent(executionData connector.ExecutionData, err error) error {
-	return eh.sendEvent(NewErroredSyntheticTriggerFinishedEventFactory(eh.event, executionData, err))
+// sendFinishedEvent sends the finished event and deletes the saved state of the batch afterwards, so that the test is resumed if the event could not be sent.
+func (eh *SyntheticTriggerEventHandler) sendFinishedEvent(replyCtx context.Context, factory adapter.CloudEventFactoryInterface) error {
+	err := eh.sendEvent(factory)
+	if err != nil {
+		return err
+	}
+
+	eh.deleteBatchState(replyCtx)
+	return nil
+}
+
+// saveBatchState saves the state of the current batch, so that waiting for it can be resumed after a restart.
+func (eh *SyntheticTriggerEventHandler) saveBatchState(workCtx context.Context, batch connector.Batch, attempts []connector.ExecutionData) {
+	if eh.batchStates == nil || eh.batchState == nil {
+		return
+	}
+
+	eh.batchState.Batch = batch.GetState()
+	eh.batchState.Attempts = attempts
+	eh.batchState.EphemeralMonitor = eh.ephemeralMonitor
+	eh.batchState.EnabledMonitorIds = eh.enabledMonitorIds
+
+	err := eh.batchStates.Save(workCtx, *eh.batchState)
+	if err != nil {
+		log.WithError(err).Error("Could not save state of synthetic batch, waiting for it cannot be resumed after a restart")
+		return
+	}
+
+	eh.isBatchStateSaved = true
+}
+
+func (eh *SyntheticTriggerEventHandler) deleteBatchState(replyCtx context.Context) {
+	if eh.batchStates == nil || !eh.isBatchStateSaved {
+		return
+	}
+
+	err := eh.batchStates.Delete(replyCtx, *eh.batchState)
+	if err != nil {
+		log.WithError(err).Error("Could not delete state of synthetic batch")
+		return
+	}
+
+	eh.isBatchStateSaved = false
 }
 
 func (eh *SyntheticTriggerEventHandler) sendEvent(factory adapter.CloudEventFactoryInterface) error {
@@ -0,0 +1,709 @@
+package synthetic
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	"github.com/keptn-contrib/dynatrace-service/internal/common"
+	"github.com/keptn-contrib/dynatrace-service/internal/credentials"
+	"github.com/keptn-contrib/dynatrace-service/internal/dynatrace"
+	"github.com/keptn-contrib/dynatrace-service/internal/keptn"
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+	"github.com/stretchr/testify/assert"
+)
+
+// dynatraceClientMock records the bodies posted to each path as well as the deleted paths and returns an empty JSON object.
+type dynatraceClientMock struct {
+	posts   map[string][][]byte
+	deletes []string
+}
+
+func newDynatraceClientMock() *dynatraceClientMock {
+	return &dynatraceClientMock{posts: map[string][][]byte{}}
+}
+
+func (m *dynatraceClientMock) Get(ctx context.Context, apiPath string) ([]byte, error) {
+	panic("Get() should not be needed in this mock!")
+}
+
+func (m *dynatraceClientMock) Post(ctx context.Context, apiPath string, body []byte) ([]byte, error) {
+	m.posts[apiPath] = append(m.posts[apiPath], body)
+	return []byte("{}"), nil
+}
+
+func (m *dynatraceClientMock) PostTextPlain(ctx context.Context, apiPath string, body []byte) ([]byte, error) {
+	panic("PostTextPlain() should not be needed in this mock!")
+}
+
+func (m *dynatraceClientMock) Put(ctx context.Context, apiPath string, body []byte) ([]byte, error) {
+	panic("Put() should not be needed in this mock!")
+}
+
+func (m *dynatraceClientMock) Delete(ctx context.Context, apiPath string) ([]byte, error) {
+	m.deletes = append(m.deletes, apiPath)
+	return []byte("{}"), nil
+}
+
+func (m *dynatraceClientMock) Credentials() *credentials.DynatraceCredentials {
+	panic("Credentials() should not be needed in this mock!")
+}
+
+// getInfoEvents gets the info events sent to the Dynatrace events API.
+func (m *dynatraceClientMock) getInfoEvents(t *testing.T) []dynatrace.InfoEvent {
+	infoEvents := []dynatrace.InfoEvent{}
+	for _, body := range m.posts["/api/v1/events"] {
+		infoEvent := dynatrace.InfoEvent{}
+		err := json.Unmarshal(body, &infoEvent)
+		assert.NoError(t, err)
+		infoEvents = append(infoEvents, infoEvent)
+	}
+
+	return infoEvents
+}
+
+type syntheticConnectorMock struct {
+	monitors   []dynatrace.SyntheticMonitor
+	batch      *batchMock
+	triggerErr error
+	ingested   []connector.ExecutionData
+
+	// dataMonitorIds and dataStartTime are the arguments WaitForData was called with
+	dataMonitorIds []string
+	dataStartTime  time.Time
+}
+
+func (m *syntheticConnectorMock) ResolveMonitors(workCtx context.Context, selection connector.MonitorSelection) ([]dynatrace.SyntheticMonitor, error) {
+	return m.monitors, nil
+}
+
+func (m *syntheticConnectorMock) FindRelatedMonitors(workCtx context.Context, project string, stage string, service string) ([]string, error) {
+	panic("FindRelatedMonitors() should not be needed in this mock!")
+}
+
+func (m *syntheticConnectorMock) Trigger(workCtx context.Context, selection connector.MonitorSelection, locations []string, options connector.ExecutionOptions) (connector.Batch, error) {
+	if m.triggerErr != nil {
+		return nil, m.triggerErr
+	}
+
+	return m.batch, nil
+}
+
+func (m *syntheticConnectorMock) RestoreBatch(state connector.TriggeredBatch) connector.Batch {
+	return m.batch
+}
+
+func (m *syntheticConnectorMock) WaitForData(workCtx context.Context, monitorIds []string, startTime time.Time, policy connector.PollingPolicy) error {
+	m.dataMonitorIds = monitorIds
+	m.dataStartTime = startTime
+	return nil
+}
+
+func (m *syntheticConnectorMock) IngestSyntheticMetrics(workCtx context.Context, executionData connector.ExecutionData, projectName string, serviceName string, stageName string) (dynatrace.MetricsIngestResponse, error) {
+	m.ingested = append(m.ingested, executionData)
+	return dynatrace.MetricsIngestResponse{}, nil
+}
+
+// batchMock is a batch with a fixed outcome, which is re-triggered as retryBatch.
+type batchMock struct {
+	executionData    connector.ExecutionData
+	failedExecutions []connector.ExecutionNotSuccessful
+	successRate      float64
+	reports          []connector.ExecutionReport
+	retryBatch       *batchMock
+	retriggered      [][]connector.MonitorLocation
+
+	// waiting is closed once Wait is called, which then blocks until the context is done, if set
+	waiting chan struct{}
+}
+
+func (m *batchMock) GetExecutionData() connector.ExecutionData {
+	return m.executionData
+}
+
+func (m *batchMock) GetState() connector.TriggeredBatch {
+	return connector.TriggeredBatch{ExecutionData: m.executionData, TriggerTime: time.Date(2022, 4, 15, 10, 0, 0, 0, time.UTC)}
+}
+
+func (m *batchMock) Wait(workCtx context.Context, policy connector.PollingPolicy) (connector.BatchResponseBody, float64, error) {
+	if m.waiting != nil {
+		close(m.waiting)
+		<-workCtx.Done()
+		return connector.BatchResponseBody{}, 0, workCtx.Err()
+	}
+
+	return connector.BatchResponseBody{BatchStatus: "SUCCESS", FailedExecutions: m.failedExecutions}, m.successRate, nil
+}
+
+func (m *batchMock) Report(workCtx context.Context) ([]connector.ExecutionReport, error) {
+	return m.reports, nil
+}
+
+func (m *batchMock) Retrigger(workCtx context.Context, monitorLocations []connector.MonitorLocation) (connector.Batch, error) {
+	m.retriggered = append(m.retriggered, monitorLocations)
+	if m.retryBatch == nil {
+		return nil, errors.New("batch cannot be re-triggered")
+	}
+
+	return m.retryBatch, nil
+}
+
+type keptnClientMock struct {
+	eventSink []*cloudevents.Event
+	sendErr   error
+}
+
+func (m *keptnClientMock) GetCustomQueries(project string, stage string, service string) (*keptn.CustomQueries, error) {
+	panic("GetCustomQueries() should not be needed in this mock!")
+}
+
+func (m *keptnClientMock) GetShipyard() (*keptnv2.Shipyard, error) {
+	panic("GetShipyard() should not be needed in this mock!")
+}
+
+func (m *keptnClientMock) SendCloudEvent(factory adapter.CloudEventFactoryInterface) error {
+	if m.sendErr != nil {
+		return m.sendErr
+	}
+
+	ce, err := factory.CreateCloudEvent()
+	if err != nil {
+		return fmt.Errorf("could not create cloud event: %w", err)
+	}
+
+	m.eventSink = append(m.eventSink, ce)
+	return nil
+}
+
+// eventClientMock returns the data of the previous test finished event, if any.
+type eventClientMock struct {
+	previous *SyntheticTriggerFinishedEventData
+}
+
+func (m *eventClientMock) IsPartOfRemediation(event adapter.EventContentAdapter) (bool, error) {
+	panic("IsPartOfRemediation() should not be needed in this mock!")
+}
+
+func (m *eventClientMock) FindProblemID(keptnEvent adapter.EventContentAdapter) (string, error) {
+	panic("FindProblemID() should not be needed in this mock!")
+}
+
+func (m *eventClientMock) GetImageAndTag(keptnEvent adapter.EventContentAdapter) common.ImageAndTag {
+	panic("GetImageAndTag() should not be needed in this mock!")
+}
+
+func (m *eventClientMock) GetPreviousTestFinishedEventData(event adapter.EventContentAdapter, source string, eventData interface{}) (bool, error) {
+	if m.previous == nil {
+		return false, nil
+	}
+
+	data, err := json.Marshal(m.previous)
+	if err != nil {
+		return false, err
+	}
+	return true, json.Unmarshal(data, eventData)
+}
+
+// syntheticResourcesClientMock behaves as if no synthetic.yaml exists and returns the checks as synthetic-checks.yaml, if any.
+// Uploaded reports are kept by their resource URI, the uploaded history replaces the history.
+type syntheticResourcesClientMock struct {
+	checks  string
+	reports map[string][]byte
+	history string
+}
+
+func (m *syntheticResourcesClientMock) GetSyntheticMonitors(project string, stage string, service string) (string, error) {
+	return "", &keptn.ResourceNotFoundError{}
+}
+
+func (m *syntheticResourcesClientMock) GetSyntheticChecks(project string, stage string, service string) (string, error) {
+	if m.checks == "" {
+		return "", &keptn.ResourceNotFoundError{}
+	}
+	return m.checks, nil
+}
+
+func (m *syntheticResourcesClientMock) UploadSyntheticReport(project string, stage string, service string, resourceURI string, report []byte) error {
+	if m.reports == nil {
+		m.reports = make(map[string][]byte)
+	}
+	m.reports[resourceURI] = report
+	return nil
+}
+
+func (m *syntheticResourcesClientMock) GetSyntheticHistory(project string, stage string, service string) (string, error) {
+	if m.history == "" {
+		return "", &keptn.ResourceNotFoundError{}
+	}
+	return m.history, nil
+}
+
+func (m *syntheticResourcesClientMock) UploadSyntheticHistory(project string, stage string, service string, history []byte) error {
+	m.history = string(history)
+	return nil
+}
+
+func getSyntheticTriggerFinishedEventData(t *testing.T, events []*cloudevents.Event) SyntheticTriggerFinishedEventData {
+	if !assert.Len(t, events, 2) {
+		return SyntheticTriggerFinishedEventData{}
+	}
+
+	assert.Equal(t, keptnv2.GetStartedEventType(keptnv2.TestTaskName), events[0].Type())
+	assert.Equal(t, keptnv2.GetFinishedEventType(keptnv2.TestTaskName), events[1].Type())
+
+	data := SyntheticTriggerFinishedEventData{}
+	err := events[1].DataAs(&data)
+	assert.NoError(t, err)
+	return data
+}
+
+func createTestMonitors() []dynatrace.SyntheticMonitor {
+	return []dynatrace.SyntheticMonitor{
+		{EntityID: "HTTP_CHECK-1", Name: "frontend", Enabled: true, Locations: []string{"GEOLOCATION-1", "GEOLOCATION-2"}},
+	}
+}
+
+// TestSyntheticTriggerEventHandler_HandleEvent_RetriesFailedExecutions tests that the injected connector is used and that a failed execution is re-triggered in a new batch.
+func TestSyntheticTriggerEventHandler_HandleEvent_RetriesFailedExecutions(t *testing.T) {
+	retryBatch := &batchMock{
+		executionData: connector.ExecutionData{
+			BatchId:             "2",
+			MonitorIds:          []string{"HTTP_CHECK-1"},
+			ExecutionIds:        []string{"3"},
+			TriggeredExecutions: []connector.TriggeredExecution{{ExecutionId: "3", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-2"}},
+		},
+		successRate: 100,
+		reports: []connector.ExecutionReport{
+			{ExecutionId: "3", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-2", SimpleResults: connector.ExecutionSimpleResults{StartTimestamp: 1650016890000, Duration: 1500}},
+		},
+	}
+
+	batch := &batchMock{
+		executionData: connector.ExecutionData{
+			BatchId:      "1",
+			MonitorIds:   []string{"HTTP_CHECK-1"},
+			ExecutionIds: []string{"1", "2"},
+			TriggeredExecutions: []connector.TriggeredExecution{
+				{ExecutionId: "1", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1"},
+				{ExecutionId: "2", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-2"},
+			},
+		},
+		failedExecutions: []connector.ExecutionNotSuccessful{{ExecutionId: "2", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-2"}},
+		successRate:      50,
+		retryBatch:       retryBatch,
+	}
+
+	dtClient := newDynatraceClientMock()
+	sClient := &syntheticConnectorMock{monitors: createTestMonitors(), batch: batch}
+	kClient := &keptnClientMock{}
+	event := createTestSyntheticTriggerAdapter(t, map[string]interface{}{
+		"monitorId": "HTTP_CHECK-1",
+		"waitFor":   "execution",
+		"retries":   1,
+	})
+
+	handler := NewSyntheticTriggerEventHandler(event, dtClient, sClient, kClient, nil, &syntheticResourcesClientMock{}, nil, NewRunningTests(), nil, nil)
+	err := handler.HandleEvent(context.TODO(), context.TODO())
+	assert.NoError(t, err)
+
+	assert.Equal(t, [][]connector.MonitorLocation{{{MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-2"}}}, batch.retriggered)
+	assert.Len(t, sClient.ingested, 1)
+
+	data := getSyntheticTriggerFinishedEventData(t, kClient.eventSink)
+	assert.Equal(t, keptnv2.StatusSucceeded, data.Status)
+	assert.Equal(t, keptnv2.ResultPass, data.Result)
+	assert.Equal(t, "1", data.SyntheticExecution.BatchId)
+	assert.Equal(t, []string{"1", "3"}, data.SyntheticExecution.ExecutionIds)
+	assert.Empty(t, data.SyntheticExecution.FailedExecutions)
+	assert.Equal(t, 100.0, data.SyntheticExecution.SuccessRate)
+	assert.Len(t, data.SyntheticExecution.Attempts, 2)
+	assert.Equal(t, &SyntheticTestTimeframe{Start: "2022-04-15T10:00:00.000Z", End: "2022-04-15T10:01:31.500Z", Duration: "1m31.5s"}, data.Test)
+
+	infoEvents := dtClient.getInfoEvents(t)
+	if assert.Len(t, infoEvents, 2) {
+		assert.Equal(t, "Synthetic batch started", infoEvents[0].Title)
+		assert.Equal(t, "Synthetic batch finished", infoEvents[1].Title)
+		assert.Equal(t, "100.00", infoEvents[1].CustomProperties["SuccessRate"])
+		assert.Equal(t, "pass", infoEvents[1].CustomProperties["Result"])
+	}
+}
+
+// TestSyntheticTriggerEventHandler_HandleEvent_WaitsForDataOfAllAttempts tests that after re-triggering failed executions, the data of all monitors of the test is waited for since the first batch was triggered.
+func TestSyntheticTriggerEventHandler_HandleEvent_WaitsForDataOfAllAttempts(t *testing.T) {
+	retryBatch := &batchMock{
+		executionData: connector.ExecutionData{
+			BatchId:             "2",
+			MonitorIds:          []string{"HTTP_CHECK-2"},
+			ExecutionIds:        []string{"3"},
+			TriggeredExecutions: []connector.TriggeredExecution{{ExecutionId: "3", MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1"}},
+		},
+		successRate: 100,
+	}
+
+	batch := &batchMock{
+		executionData: connector.ExecutionData{
+			BatchId:      "1",
+			MonitorIds:   []string{"HTTP_CHECK-1", "HTTP_CHECK-2"},
+			ExecutionIds: []string{"1", "2"},
+			TriggeredExecutions: []connector.TriggeredExecution{
+				{ExecutionId: "1", MonitorId: "HTTP_CHECK-1", LocationId: "GEOLOCATION-1"},
+				{ExecutionId: "2", MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1"},
+			},
+		},
+		failedExecutions: []connector.ExecutionNotSuccessful{{ExecutionId: "2", MonitorId: "HTTP_CHECK-2", LocationId: "GEOLOCATION-1"}},
+		successRate:      50,
+		retryBatch:       retryBatch,
+	}
+
+	sClient := &syntheticConnectorMock{monitors: createTestMonitors(), batch: batch}
+	kClient := &keptnClientMock{}
+	event := createTestSyntheticTriggerAdapter(t, map[string]interface{}{
+		"monitorId": "HTTP_CHECK-1",
+		"waitFor":   "data",
+		"retries":   1,
+	})
+
+	handler := NewSyntheticTriggerEventHandler(event, newDynatraceClientMock(), sClient, kClient, nil, &syntheticResourcesClientMock{}, nil, NewRunningTests(), nil, nil)
+	err := handler.HandleEvent(context.TODO(), context.TODO())
+	assert.NoError(t, err)
+
+	assert.Len(t, batch.retriggered, 1)
+	assert.Equal(t, []string{"HTTP_CHECK-1", "HTTP_CHECK-2"}, sClient.dataMonitorIds)
+	assert.Equal(t, time.Date(2022, 4, 15, 10, 0, 0, 0, time.UTC), sClient.dataStartTime)
+
+	data := getSyntheticTriggerFinishedEventData(t, kClient.eventSink)
+	assert.Equal(t, keptnv2.ResultPass, data.Result)
+}
+
+// TestSyntheticTriggerEventHandler_HandleEvent_TriggerFails tests that an errored finished event is sent if the batch cannot be triggered.
+func TestSyntheticTriggerEventHandler_HandleEvent_TriggerFails(t *testing.T) {
+	dtClient := newDynatraceClientMock()
+	sClient := &syntheticConnectorMock{monitors: createTestMonitors(), triggerErr: errors.New("could not trigger batch")}
+	kClient := &keptnClientMock{}
+	event := createTestSyntheticTriggerAdapter(t, map[string]interface{}{
+		"monitorId": "HTTP_CHECK-1",
+		"waitFor":   "execution",
+	})
+
+	rClient := &syntheticResourcesClientMock{}
+	handler := NewSyntheticTriggerEventHandler(event, dtClient, sClient, kClient, nil, rClient, nil, NewRunningTests(), nil, nil)
+	err := handler.HandleEvent(context.TODO(), context.TODO())
+	assert.NoError(t, err)
+
+	data := getSyntheticTriggerFinishedEventData(t, kClient.eventSink)
+	assert.Equal(t, keptnv2.StatusErrored, data.Status)
+	assert.Equal(t, keptnv2.ResultFailed, data.Result)
+	assert.Equal(t, "could not trigger batch", data.Message)
+	assert.Nil(t, data.Test)
+	assert.Empty(t, sClient.ingested)
+	assert.Empty(t, dtClient.getInfoEvents(t))
+	assert.Nil(t, data.SyntheticExecution.Reports)
+	assert.Empty(t, rClient.reports)
+}
+
+// TestSyntheticTriggerEventHandler_HandleEvent_Aborted tests that a test aborted while waiting sends an aborted finished event with the results so far and does not keep its state to be resumed.
+func TestSyntheticTriggerEventHandler_HandleEvent_Aborted(t *testing.T) {
+	batch := &batchMock{
+		executionData: connector.ExecutionData{
+			BatchId:      "1",
+			MonitorIds:   []string{"HTTP_CHECK-1"},
+			ExecutionIds: []string{"1"},
+		},
+		waiting: make(chan struct{}),
+	}
+
+	batchStates := NewFileBatchStateStore(t.TempDir())
+	runningTests := NewRunningTests()
+	sClient := &syntheticConnectorMock{monitors: createTestMonitors(), batch: batch}
+	kClient := &keptnClientMock{}
+	event := createTestSyntheticTriggerAdapter(t, map[string]interface{}{
+		"monitorId": "HTTP_CHECK-1",
+		"waitFor":   "execution",
+	})
+
+	handler := NewSyntheticTriggerEventHandler(event, newDynatraceClientMock(), sClient, kClient, nil, &syntheticResourcesClientMock{}, batchStates, runningTests, nil, nil)
+
+	done := make(chan error)
+	go func() {
+		done <- handler.HandleEvent(context.TODO(), context.TODO())
+	}()
+
+	<-batch.waiting
+	assert.Equal(t, 0, runningTests.Abort("context-2", "aborted by test"))
+	assert.Equal(t, 1, runningTests.Abort("context-1", "aborted by test"))
+	assert.Error(t, <-done)
+
+	data := getSyntheticTriggerFinishedEventData(t, kClient.eventSink)
+	assert.Equal(t, keptnv2.StatusAborted, data.Status)
+	assert.Equal(t, "synthetic test aborted: aborted by test", data.Message)
+	assert.Equal(t, "1", data.SyntheticExecution.BatchId)
+	assert.Equal(t, []string{"1"}, data.SyntheticExecution.ExecutionIds)
+	assert.NotNil(t, data.Test)
+
+	states, err := batchStates.List(context.TODO())
+	assert.NoError(t, err)
+	assert.Empty(t, states)
+	assert.Equal(t, 0, runningTests.Abort("context-1", "aborted by test"))
+}
+
+// TestSyntheticTriggerEventHandler_ResumeBatch_SavesDeletedMonitor tests that the deletion of the ephemeral monitor is saved, so that the test resumed after failing to send the finished event does not delete it again.
+func TestSyntheticTriggerEventHandler_ResumeBatch_SavesDeletedMonitor(t *testing.T) {
+	batch := &batchMock{
+		executionData: connector.ExecutionData{
+			BatchId:      "1",
+			MonitorIds:   []string{"HTTP_CHECK-1"},
+			ExecutionIds: []string{"1"},
+		},
+		successRate: 100,
+	}
+
+	batchStates := NewFileBatchStateStore(t.TempDir())
+	dtClient := newDynatraceClientMock()
+	sClient := &syntheticConnectorMock{batch: batch}
+	kClient := &keptnClientMock{sendErr: errors.New("Keptn is not available")}
+	event := createTestSyntheticTriggerAdapter(t, map[string]interface{}{
+		"monitorId": "HTTP_CHECK-1",
+		"waitFor":   "execution",
+	})
+
+	state := BatchState{
+		KeptnContext:     "context-1",
+		TriggeredEventId: "triggered-1",
+		StartTime:        time.Date(2022, 4, 15, 10, 0, 0, 0, time.UTC),
+		Batch:            batch.GetState(),
+		EphemeralMonitor: &EphemeralMonitor{CreatedMonitorId: "HTTP_CHECK-1"},
+		Event:            event.GetCloudEvent(),
+	}
+	err := batchStates.Save(context.TODO(), state)
+	assert.NoError(t, err)
+
+	handler := NewSyntheticTriggerEventHandler(event, dtClient, sClient, kClient, nil, &syntheticResourcesClientMock{}, batchStates, nil, nil, nil)
+	err = handler.ResumeBatch(context.TODO(), context.TODO(), state)
+	assert.Error(t, err)
+	assert.Len(t, dtClient.deletes, 1)
+
+	states, err := batchStates.List(context.TODO())
+	assert.NoError(t, err)
+	if assert.Len(t, states, 1) {
+		assert.Equal(t, &EphemeralMonitor{CreatedMonitorId: "HTTP_CHECK-1", DeletedMonitorId: "HTTP_CHECK-1"}, states[0].EphemeralMonitor)
+	}
+}
+
+// TestSyntheticTriggerEventHandler_HandleEvent_LocalRunner tests that the declared checks are executed by the service, reported as third-party synthetic monitors and evaluated like a batch.
+func TestSyntheticTriggerEventHandler_HandleEvent_LocalRunner(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte(`{"status": "UP"}`))
+	}))
+	defer server.Close()
+
+	dtClient := newDynatraceClientMock()
+	kClient := &keptnClientMock{}
+	rClient := &syntheticResourcesClientMock{checks: `
+checks:
+  - name: health
+    url: ` + server.URL + `/health
+    expectedStatus: 200
+    bodyRegex: UP
+  - name: login
+    url: ` + server.URL + `/login
+`}
+	event := createTestSyntheticTriggerAdapter(t, map[string]interface{}{
+		"runner":     "local",
+		"thresholds": map[string]interface{}{"pass": map[string]interface{}{"minSuccessRate": 100}},
+	})
+
+	handler := NewSyntheticTriggerEventHandler(event, dtClient, &syntheticConnectorMock{}, kClient, nil, rClient, nil, NewRunningTests(), nil, nil)
+	err := handler.HandleEvent(context.TODO(), context.TODO())
+	assert.NoError(t, err)
+
+	data := getSyntheticTriggerFinishedEventData(t, kClient.eventSink)
+	assert.Equal(t, keptnv2.StatusSucceeded, data.Status)
+	assert.Equal(t, keptnv2.ResultFailed, data.Result)
+	assert.Equal(t, "local-context-1", data.SyntheticExecution.BatchId)
+	assert.Equal(t, []string{"keptn-easytravel-staging-frontend-keptn-health", "keptn-easytravel-staging-frontend-keptn-login"}, data.SyntheticExecution.MonitorIds)
+	assert.Equal(t, 50.0, data.SyntheticExecution.SuccessRate)
+	assert.Len(t, data.SyntheticExecution.FailedExecutions, 1)
+	assert.Len(t, data.SyntheticExecution.Executions, 2)
+	assert.NotNil(t, data.Test)
+
+	pushedTests := getPushedThirdPartySyntheticTests(t, dtClient)
+	if assert.Len(t, pushedTests, 1) {
+		assert.Equal(t, "Keptn", pushedTests[0].SyntheticEngineName)
+		assert.Len(t, pushedTests[0].Tests, 2)
+	}
+
+	infoEvents := dtClient.getInfoEvents(t)
+	if assert.Len(t, infoEvents, 2) {
+		assert.Empty(t, infoEvents[0].AttachRules.EntityIds)
+		assert.Equal(t, "fail", infoEvents[1].CustomProperties["Result"])
+	}
+	assert.Equal(t, &SyntheticReports{JUnit: "synthetic/reports/context-1.xml", JSON: "synthetic/reports/context-1.json"}, data.SyntheticExecution.Reports)
+	assert.Contains(t, string(rClient.reports["synthetic/reports/context-1.xml"]), `<testsuites name="Synthetic test of service frontend in stage staging of project easytravel" tests="2" failures="1" errors="0">`)
+	assert.Contains(t, string(rClient.reports["synthetic/reports/context-1.json"]), `"result": "fail"`)
+}
+
+// TestSyntheticTriggerEventHandler_HandleEvent_LocalRunnerWithoutChecks tests that an errored finished event is sent if no checks are declared.
+func TestSyntheticTriggerEventHandler_HandleEvent_LocalRunnerWithoutChecks(t *testing.T) {
+	kClient := &keptnClientMock{}
+	event := createTestSyntheticTriggerAdapter(t, map[string]interface{}{
+		"test": map[string]interface{}{"runner": "local"},
+	})
+
+	handler := NewSyntheticTriggerEventHandler(event, newDynatraceClientMock(), &syntheticConnectorMock{}, kClient, nil, &syntheticResourcesClientMock{}, nil, NewRunningTests(), nil, nil)
+	err := handler.HandleEvent(context.TODO(), context.TODO())
+	assert.NoError(t, err)
+
+	data := getSyntheticTriggerFinishedEventData(t, kClient.eventSink)
+	assert.Equal(t, keptnv2.StatusErrored, data.Status)
+	assert.Contains(t, data.Message, "could not get synthetic checks")
+}
+
+// TestSyntheticTriggerEventHandler_HandleEvent_ComparesWithPreviousTest tests that monitors failing since the previous test are reported and result in a warning.
+func TestSyntheticTriggerEventHandler_HandleEvent_ComparesWithPreviousTest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	healthId := "keptn-easytravel-staging-frontend-keptn-health"
+	loginId := "keptn-easytravel-staging-frontend-keptn-login"
+	eClient := &eventClientMock{
+		previous: &SyntheticTriggerFinishedEventData{
+			Test: &SyntheticTestTimeframe{Start: "2022-04-15T10:00:00.000Z", End: "2022-04-15T10:00:01.000Z", Duration: "1s"},
+			SyntheticExecution: SyntheticExecution{
+				BatchId:          "local-context-0",
+				MonitorIds:       []string{healthId, loginId},
+				FailedExecutions: []connector.ExecutionNotSuccessful{{ExecutionId: "1", MonitorId: healthId, LocationId: "keptn"}},
+			},
+		},
+	}
+
+	kClient := &keptnClientMock{}
+	rClient := &syntheticResourcesClientMock{checks: `
+checks:
+  - name: health
+    url: ` + server.URL + `/health
+  - name: login
+    url: ` + server.URL + `/login
+`}
+	event := createTestSyntheticTriggerAdapter(t, map[string]interface{}{
+		"runner":     "local",
+		"comparison": map[string]interface{}{"maxResponseTimeIncrease": 50},
+	})
+
+	handler := NewSyntheticTriggerEventHandler(event, newDynatraceClientMock(), &syntheticConnectorMock{}, kClient, eClient, rClient, nil, NewRunningTests(), nil, nil)
+	err := handler.HandleEvent(context.TODO(), context.TODO())
+	assert.NoError(t, err)
+
+	data := getSyntheticTriggerFinishedEventData(t, kClient.eventSink)
+	assert.Equal(t, keptnv2.StatusSucceeded, data.Status)
+	assert.Equal(t, keptnv2.ResultWarning, data.Result)
+	assert.Equal(t, "synthetic test result warning: monitors "+loginId+" failed which succeeded in the previous test", data.Message)
+	if assert.NotNil(t, data.SyntheticExecution.Comparison) {
+		assert.Equal(t, "local-context-0", data.SyntheticExecution.Comparison.PreviousBatchId)
+		assert.Equal(t, []string{loginId}, data.SyntheticExecution.Comparison.NewlyFailingMonitorIds)
+		assert.Equal(t, []string{healthId}, data.SyntheticExecution.Comparison.RecoveredMonitorIds)
+	}
+}
+
+// TestSyntheticTriggerEventHandler_HandleEvent_QuarantinedMonitors tests that quarantined monitors are reported, but excluded from the result, and that the history is updated if monitors are quarantined based on their flakiness.
+func TestSyntheticTriggerEventHandler_HandleEvent_QuarantinedMonitors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/health" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Write([]byte("ok"))
+	}))
+	defer server.Close()
+
+	healthId := "keptn-easytravel-staging-frontend-keptn-health"
+	loginId := "keptn-easytravel-staging-frontend-keptn-login"
+	kClient := &keptnClientMock{}
+	rClient := &syntheticResourcesClientMock{
+		checks: `
+checks:
+  - name: health
+    url: ` + server.URL + `/health
+  - name: login
+    url: ` + server.URL + `/login
+`,
+		history: `{"monitors": [{"monitorId": "` + loginId + `", "locationId": "keptn", "successful": [true, false, true, false]}]}`,
+	}
+	event := createTestSyntheticTriggerAdapter(t, map[string]interface{}{
+		"runner":     "local",
+		"thresholds": map[string]interface{}{"pass": map[string]interface{}{"minSuccessRate": 100}},
+		"quarantine": map[string]interface{}{"minFlakinessScore": 0.5},
+	})
+
+	handler := NewSyntheticTriggerEventHandler(event, newDynatraceClientMock(), &syntheticConnectorMock{}, kClient, nil, rClient, nil, NewRunningTests(), nil, nil)
+	err := handler.HandleEvent(context.TODO(), context.TODO())
+	assert.NoError(t, err)
+
+	data := getSyntheticTriggerFinishedEventData(t, kClient.eventSink)
+	assert.Equal(t, keptnv2.StatusSucceeded, data.Status)
+	assert.Equal(t, keptnv2.ResultPass, data.Result)
+	assert.Equal(t, 50.0, data.SyntheticExecution.SuccessRate)
+	assert.Len(t, data.SyntheticExecution.FailedExecutions, 1)
+	assert.Equal(t, []string{loginId}, data.SyntheticExecution.QuarantinedMonitorIds)
+	assert.Equal(t, []MonitorFlakiness{
+		{MonitorId: healthId, LocationId: "keptn", Score: 0, Executions: 1},
+		{MonitorId: loginId, LocationId: "keptn", Score: 0.75, Executions: 5},
+	}, data.SyntheticExecution.Flakiness)
+
+	history, err := parseMonitorHistory(rClient.history)
+	assert.NoError(t, err)
+	assert.Equal(t, &monitorHistory{Monitors: []monitorLocationHistory{
+		{MonitorId: loginId, LocationId: "keptn", Successful: []bool{true, false, true, false, false}},
+		{MonitorId: healthId, LocationId: "keptn", Successful: []bool{true}},
+	}, BatchIds: []string{"local-context-1"}}, history)
+}
+
+// TestSyntheticTriggerEventHandler_HandleEvent_QuarantinedMonitorsWithoutFlakiness tests that the history is not updated if monitors are not quarantined based on their flakiness.
+func TestSyntheticTriggerEventHandler_HandleEvent_QuarantinedMonitorsWithoutFlakiness(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	healthId := "keptn-easytravel-staging-frontend-keptn-health"
+	kClient := &keptnClientMock{}
+	rClient := &syntheticResourcesClientMock{
+		checks: `
+checks:
+  - name: health
+    url: ` + server.URL + `/health
+`,
+	}
+	event := createTestSyntheticTriggerAdapter(t, map[string]interface{}{
+		"runner":     "local",
+		"thresholds": map[string]interface{}{"pass": map[string]interface{}{"minSuccessRate": 100}},
+		"quarantine": map[string]interface{}{"monitorIds": []string{healthId}},
+	})
+
+	handler := NewSyntheticTriggerEventHandler(event, newDynatraceClientMock(), &syntheticConnectorMock{}, kClient, nil, rClient, nil, NewRunningTests(), nil, nil)
+	err := handler.HandleEvent(context.TODO(), context.TODO())
+	assert.NoError(t, err)
+
+	data := getSyntheticTriggerFinishedEventData(t, kClient.eventSink)
+	assert.Equal(t, keptnv2.ResultPass, data.Result)
+	assert.Equal(t, []string{healthId}, data.SyntheticExecution.QuarantinedMonitorIds)
+	assert.Empty(t, data.SyntheticExecution.Flakiness)
+	assert.Empty(t, rClient.history)
+}
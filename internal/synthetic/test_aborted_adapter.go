@@ -0,0 +1,89 @@
+package synthetic
+
+import (
+	cloudevents "github.com/cloudevents/sdk-go/v2"
+	"github.com/keptn-contrib/dynatrace-service/internal/adapter"
+	keptnv2 "github.com/keptn/go-utils/pkg/lib/v0_2_0"
+)
+
+// SequenceAbortedEventType is the type of the event sent by Keptn if a sequence is aborted.
+const SequenceAbortedEventType = "sh.keptn.event.sequence.aborted"
+
+// TestAbortedEventType is the type of an event aborting a running test.
+const TestAbortedEventType = "sh.keptn.event.test.aborted"
+
+type TestAbortedAdapterInterface interface {
+	adapter.EventContentAdapter
+}
+
+// TestAbortedAdapter is a content adaptor for events of type sh.keptn.event.sequence.aborted and sh.keptn.event.test.aborted
+type TestAbortedAdapter struct {
+	event      keptnv2.EventData
+	cloudEvent adapter.CloudEventAdapter
+}
+
+// NewTestAbortedAdapterFromEvent creates a new TestAbortedAdapter from a cloudevents Event
+func NewTestAbortedAdapterFromEvent(e cloudevents.Event) (*TestAbortedAdapter, error) {
+	ceAdapter := adapter.NewCloudEventAdapter(e)
+
+	taData := &keptnv2.EventData{}
+	err := ceAdapter.PayloadAs(taData)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TestAbortedAdapter{
+		event:      *taData,
+		cloudEvent: ceAdapter,
+	}, nil
+}
+
+// GetShKeptnContext returns the shkeptncontext
+func (a TestAbortedAdapter) GetShKeptnContext() string {
+	return a.cloudEvent.GetShKeptnContext()
+}
+
+// GetSource returns the source specified in the CloudEvent context
+func (a TestAbortedAdapter) GetSource() string {
+	return a.cloudEvent.GetSource()
+}
+
+// GetEvent returns the event type, i.e. either sh.keptn.event.sequence.aborted or sh.keptn.event.test.aborted
+func (a TestAbortedAdapter) GetEvent() string {
+	return a.cloudEvent.GetType()
+}
+
+// GetProject returns the project
+func (a TestAbortedAdapter) GetProject() string {
+	return a.event.Project
+}
+
+// GetStage returns the stage
+func (a TestAbortedAdapter) GetStage() string {
+	return a.event.Stage
+}
+
+// GetService returns the service
+func (a TestAbortedAdapter) GetService() string {
+	return a.event.Service
+}
+
+// GetDeployment returns the name of the deployment
+func (a TestAbortedAdapter) GetDeployment() string {
+	return ""
+}
+
+// GetTestStrategy returns the used test strategy
+func (a TestAbortedAdapter) GetTestStrategy() string {
+	return ""
+}
+
+// GetDeploymentStrategy returns the used deployment strategy
+func (a TestAbortedAdapter) GetDeploymentStrategy() string {
+	return ""
+}
+
+// GetLabels returns a map of labels
+func (a TestAbortedAdapter) GetLabels() map[string]string {
+	return a.event.Labels
+}
@@ -0,0 +1,29 @@
+package synthetic
+
+import (
+	"context"
+	"fmt"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// TestAbortedEventHandler handles a sequence aborted or test aborted event by aborting the running synthetic tests of its Keptn context.
+type TestAbortedEventHandler struct {
+	event        TestAbortedAdapterInterface
+	runningTests *RunningTests
+}
+
+// NewTestAbortedEventHandler creates a new TestAbortedEventHandler.
+func NewTestAbortedEventHandler(event TestAbortedAdapterInterface, runningTests *RunningTests) *TestAbortedEventHandler {
+	return &TestAbortedEventHandler{
+		event:        event,
+		runningTests: runningTests,
+	}
+}
+
+// HandleEvent handles a sequence aborted or test aborted event. The aborted tests send their finished events themselves.
+func (eh *TestAbortedEventHandler) HandleEvent(workCtx context.Context, replyCtx context.Context) error {
+	abortedTests := eh.runningTests.Abort(eh.event.GetShKeptnContext(), fmt.Sprintf("the test was aborted by a %s event", eh.event.GetEvent()))
+	log.WithFields(log.Fields{"keptnContext": eh.event.GetShKeptnContext(), "abortedTests": abortedTests}).Info("Handled abort event")
+	return nil
+}
@@ -0,0 +1,64 @@
+package synthetic
+
+import (
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+	"github.com/keptn/go-utils/pkg/common/timeutils"
+)
+
+// SyntheticTestTimeframe is reported as the standard test attribute of the test finished event, so that a following evaluation uses the timeframe of the synthetic test.
+type SyntheticTestTimeframe struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+
+	// Duration is the time from triggering the first batch until the last execution finished, e.g. 1m30s
+	Duration string `json:"duration"`
+}
+
+// newTestTimeframe creates the timeframe of a test starting when its first batch was triggered and ending when its last execution finished.
+// If no execution timestamps are available, e.g. as no execution reports could be retrieved, the timeframe ends at fallbackEnd.
+func newTestTimeframe(start time.Time, executionData connector.ExecutionData, fallbackEnd time.Time) *SyntheticTestTimeframe {
+	end, found := getLastExecutionEnd(executionData)
+	if !found {
+		end = fallbackEnd
+	}
+
+	// executions are timed by Dynatrace, so a skewed clock must not result in a negative timeframe
+	if end.Before(start) {
+		end = start
+	}
+
+	return &SyntheticTestTimeframe{
+		Start:    timeutils.GetKeptnTimeStamp(start.UTC()),
+		End:      timeutils.GetKeptnTimeStamp(end.UTC()),
+		Duration: end.Sub(start).Round(time.Millisecond).String(),
+	}
+}
+
+// getLastExecutionEnd gets the time the last execution finished based on the execution reports and the timestamps of failed executions.
+func getLastExecutionEnd(executionData connector.ExecutionData) (time.Time, bool) {
+	var lastEndTimestamp int64
+	for _, execution := range executionData.Executions {
+		endTimestamp := execution.SimpleResults.StartTimestamp + execution.SimpleResults.Duration
+		if execution.SimpleResults.StartTimestamp == 0 {
+			endTimestamp = execution.ExecutionTimestamp
+		}
+
+		if endTimestamp > lastEndTimestamp {
+			lastEndTimestamp = endTimestamp
+		}
+	}
+
+	for _, failedExecution := range executionData.FailedExecutions {
+		if int64(failedExecution.ExecutionTimestamp) > lastEndTimestamp {
+			lastEndTimestamp = int64(failedExecution.ExecutionTimestamp)
+		}
+	}
+
+	if lastEndTimestamp == 0 {
+		return time.Time{}, false
+	}
+
+	return time.UnixMilli(lastEndTimestamp), true
+}
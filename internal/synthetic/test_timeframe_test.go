@@ -0,0 +1,62 @@
+package synthetic
+
+import (
+	"testing"
+	"time"
+
+	"github.com/keptn-contrib/dynatrace-service/internal/synthetic/connector"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTestTimeframe(t *testing.T) {
+	start := time.Date(2022, 4, 15, 10, 0, 0, 0, time.UTC)
+	fallbackEnd := time.Date(2022, 4, 15, 10, 5, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		executionData connector.ExecutionData
+		want          *SyntheticTestTimeframe
+	}{
+		{
+			name:          "no execution timestamps",
+			executionData: connector.ExecutionData{},
+			want:          &SyntheticTestTimeframe{Start: "2022-04-15T10:00:00.000Z", End: "2022-04-15T10:05:00.000Z", Duration: "5m0s"},
+		},
+		{
+			name: "ends with the last execution",
+			executionData: connector.ExecutionData{
+				Executions: []connector.ExecutionReport{
+					{ExecutionId: "1", SimpleResults: connector.ExecutionSimpleResults{StartTimestamp: 1650016860000, Duration: 2000}},
+					{ExecutionId: "2", SimpleResults: connector.ExecutionSimpleResults{StartTimestamp: 1650016850000, Duration: 500}},
+				},
+			},
+			want: &SyntheticTestTimeframe{Start: "2022-04-15T10:00:00.000Z", End: "2022-04-15T10:01:02.000Z", Duration: "1m2s"},
+		},
+		{
+			name: "ends with a failed execution without report",
+			executionData: connector.ExecutionData{
+				Executions: []connector.ExecutionReport{
+					{ExecutionId: "1", SimpleResults: connector.ExecutionSimpleResults{StartTimestamp: 1650016860000, Duration: 2000}},
+				},
+				FailedExecutions: []connector.ExecutionNotSuccessful{
+					{ExecutionId: "2", ExecutionTimestamp: 1650016920000},
+				},
+			},
+			want: &SyntheticTestTimeframe{Start: "2022-04-15T10:00:00.000Z", End: "2022-04-15T10:02:00.000Z", Duration: "2m0s"},
+		},
+		{
+			name: "execution timestamps before the start",
+			executionData: connector.ExecutionData{
+				Executions: []connector.ExecutionReport{
+					{ExecutionId: "1", SimpleResults: connector.ExecutionSimpleResults{StartTimestamp: 1650016790000, Duration: 2000}},
+				},
+			},
+			want: &SyntheticTestTimeframe{Start: "2022-04-15T10:00:00.000Z", End: "2022-04-15T10:00:00.000Z", Duration: "0s"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, newTestTimeframe(start, tt.executionData, fallbackEnd))
+		})
+	}
+}